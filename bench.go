@@ -0,0 +1,229 @@
+package deckgen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Benchmark holds the measurements of one benchmark, one sample per run.
+type Benchmark struct {
+	Name        string
+	NsPerOp     []float64
+	BytesPerOp  []float64
+	AllocsPerOp []float64
+}
+
+// BenchDelta compares the time per operation of a benchmark before and after a change.
+type BenchDelta struct {
+	Name          string
+	Before, After float64 // mean ns/op
+	Delta         float64 // percentage change
+	P             float64 // p-value of the Mann-Whitney U test
+	Significant   bool    // P < 0.05
+}
+
+// ParseBench reads go test -bench output, collecting repeated runs of each benchmark.
+// Benchmarks are returned in the order they first appear.
+func ParseBench(r io.Reader) ([]Benchmark, error) {
+	var benchmarks []Benchmark
+	index := map[string]int{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) < 4 || !strings.HasPrefix(f[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(f[1]); err != nil {
+			continue
+		}
+		name := strings.TrimPrefix(f[0], "Benchmark")
+		if i := strings.LastIndex(name, "-"); i > 0 {
+			if _, err := strconv.Atoi(name[i+1:]); err == nil {
+				name = name[:i]
+			}
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(benchmarks)
+			index[name] = i
+			benchmarks = append(benchmarks, Benchmark{Name: name})
+		}
+		b := &benchmarks[i]
+		for j := 2; j+1 < len(f); j += 2 {
+			v, err := strconv.ParseFloat(f[j], 64)
+			if err != nil {
+				continue
+			}
+			switch f[j+1] {
+			case "ns/op":
+				b.NsPerOp = append(b.NsPerOp, v)
+			case "B/op":
+				b.BytesPerOp = append(b.BytesPerOp, v)
+			case "allocs/op":
+				b.AllocsPerOp = append(b.AllocsPerOp, v)
+			}
+		}
+	}
+	return benchmarks, sc.Err()
+}
+
+// mean returns the average of v.
+func mean(v []float64) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+	s := 0.0
+	for _, x := range v {
+		s += x
+	}
+	return s / float64(len(v))
+}
+
+// mannwhitney returns the two-sided p-value of the Mann-Whitney U test on two samples,
+// using the normal approximation. Samples with fewer than two values give a p-value of 1.
+func mannwhitney(a, b []float64) float64 {
+	n1, n2 := len(a), len(b)
+	if n1 < 2 || n2 < 2 {
+		return 1
+	}
+	type obs struct {
+		v     float64
+		first bool
+	}
+	all := make([]obs, 0, n1+n2)
+	for _, v := range a {
+		all = append(all, obs{v, true})
+	}
+	for _, v := range b {
+		all = append(all, obs{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].v < all[j].v })
+	r1, ties := 0.0, 0.0
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		t := float64(j - i)
+		ties += t*t*t - t
+		for k := i; k < j; k++ {
+			if all[k].first {
+				r1 += rank
+			}
+		}
+		i = j
+	}
+	fn1, fn2 := float64(n1), float64(n2)
+	u := r1 - fn1*(fn1+1)/2
+	mu := fn1 * fn2 / 2
+	n := fn1 + fn2
+	sigma := math.Sqrt(fn1 * fn2 / 12 * ((n + 1) - ties/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := (math.Abs(u-mu) - 0.5) / sigma
+	if z < 0 {
+		z = 0
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// CompareBench pairs benchmarks by name, computing the change in time per operation.
+func CompareBench(before, after []Benchmark) []BenchDelta {
+	prev := map[string]Benchmark{}
+	for _, b := range before {
+		prev[b.Name] = b
+	}
+	var deltas []BenchDelta
+	for _, a := range after {
+		b, ok := prev[a.Name]
+		if !ok || len(b.NsPerOp) == 0 || len(a.NsPerOp) == 0 {
+			continue
+		}
+		d := BenchDelta{Name: a.Name, Before: mean(b.NsPerOp), After: mean(a.NsPerOp)}
+		if d.Before != 0 {
+			d.Delta = (d.After - d.Before) / d.Before * 100
+		}
+		d.P = mannwhitney(b.NsPerOp, a.NsPerOp)
+		d.Significant = d.P < 0.05
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+// benchperslide is the number of benchmarks compared on each slide.
+const benchperslide = 8
+
+// BenchSlides reads go test -bench output from before and after a change,
+// and generates slides comparing the time per operation of each benchmark
+// as paired bars, annotated with the percentage change. Changes that are
+// not statistically significant are marked with "~", as benchstat does.
+func (p *DeckGen) BenchSlides(before, after io.Reader) error {
+	b, err := ParseBench(before)
+	if err != nil {
+		return err
+	}
+	a, err := ParseBench(after)
+	if err != nil {
+		return err
+	}
+	deltas := CompareBench(b, a)
+	if len(deltas) == 0 {
+		return fmt.Errorf("no benchmarks in common")
+	}
+	t := p.themeof(nil)
+	for start := 0; start < len(deltas); start += benchperslide {
+		end := start + benchperslide
+		if end > len(deltas) {
+			end = len(deltas)
+		}
+		p.reportslide("Benchmarks: time per operation")
+		p.benchbars(Box{X: 10, Y: 12, W: 80, H: 68}, deltas[start:end])
+		p.Rect(40, 6, 1.5, p.hpct(1.5), t.Muted)
+		p.Text(41.5, 5.5, "before", t.Font, 1.2, t.Muted)
+		p.Rect(52, 6, 1.5, p.hpct(1.5), t.Accent)
+		p.Text(53.5, 5.5, "after", t.Font, 1.2, t.Muted)
+		p.EndSlide()
+	}
+	return nil
+}
+
+// benchbars draws paired before and after bars for each comparison.
+func (p *DeckGen) benchbars(area Box, deltas []BenchDelta) {
+	largest := 0.0
+	for _, d := range deltas {
+		largest = math.Max(largest, math.Max(d.Before, d.After))
+	}
+	if largest == 0 {
+		largest = 1
+	}
+	labelw := area.W * 0.3
+	barw := (area.W - labelw) * 0.75
+	rh := area.H / float64(len(deltas))
+	bh := math.Min(rh*0.35, p.hpct(2.5))
+	t := p.themeof(nil)
+	for i, d := range deltas {
+		cy := area.Top() - rh*(float64(i)+0.5)
+		p.TextEnd(area.X+labelw-1, cy-p.hpct(reportsize)/3, d.Name, t.Font, reportsize, t.Fg)
+		wb, wa := barw*d.Before/largest, barw*d.After/largest
+		x := area.X + labelw
+		p.Rect(x+wb/2, cy+bh/2, wb, bh, t.Muted)
+		p.Rect(x+wa/2, cy-bh/2, wa, bh, t.Accent)
+		color := t.Muted
+		label := fmt.Sprintf("~ (p=%.2f)", d.P)
+		if d.Significant {
+			color = "green"
+			if d.Delta > 0 {
+				color = "red"
+			}
+			label = fmt.Sprintf("%+.1f%% *", d.Delta)
+		}
+		p.Text(x+math.Max(wa, wb)+1, cy-p.hpct(reportsize)/3, label, t.Font, reportsize, color)
+	}
+}
@@ -0,0 +1,91 @@
+package deckgen
+
+import "math"
+
+// curvesteps is the number of segments used to approximate curve lengths.
+const curvesteps = 64
+
+// NewCurve makes a curve structure from (x1, y1) to (x3, y3), with control point (x2, y2).
+func NewCurve(x1, y1, x2, y2, x3, y3 float64) Curve {
+	return Curve{Xp1: x1, Yp1: y1, Xp2: x2, Yp2: y2, Xp3: x3, Yp3: y3}
+}
+
+// PointAt returns the point on the curve at parameter t (0-1).
+func (c Curve) PointAt(t float64) Point {
+	u := 1 - t
+	return Point{
+		X: u*u*c.Xp1 + 2*u*t*c.Xp2 + t*t*c.Xp3,
+		Y: u*u*c.Yp1 + 2*u*t*c.Yp2 + t*t*c.Yp3,
+	}
+}
+
+// Tangent returns the direction of travel along the curve at parameter t, in degrees.
+func (c Curve) Tangent(t float64) float64 {
+	dx := 2*(1-t)*(c.Xp2-c.Xp1) + 2*t*(c.Xp3-c.Xp2)
+	dy := 2*(1-t)*(c.Yp2-c.Yp1) + 2*t*(c.Yp3-c.Yp2)
+	if dx == 0 && dy == 0 {
+		dx, dy = c.Xp3-c.Xp1, c.Yp3-c.Yp1
+	}
+	return math.Atan2(dy, dx) * 180 / math.Pi
+}
+
+// Split divides the curve at parameter t, returning the two halves.
+// The halves keep the size, color and opacity of the original.
+func (c Curve) Split(t float64) (Curve, Curve) {
+	lerp := func(a, b float64) float64 { return a + (b-a)*t }
+	ax, ay := lerp(c.Xp1, c.Xp2), lerp(c.Yp1, c.Yp2)
+	bx, by := lerp(c.Xp2, c.Xp3), lerp(c.Yp2, c.Yp3)
+	m := c.PointAt(t)
+	first, second := c, c
+	first.Xp2, first.Yp2, first.Xp3, first.Yp3 = ax, ay, m.X, m.Y
+	second.Xp1, second.Yp1, second.Xp2, second.Yp2 = m.X, m.Y, bx, by
+	return first, second
+}
+
+// Length returns the approximate arc length of the curve, in canvas percentages.
+func (c Curve) Length() float64 {
+	l := 0.0
+	prev := c.PointAt(0)
+	for i := 1; i <= curvesteps; i++ {
+		pt := c.PointAt(float64(i) / curvesteps)
+		l += math.Hypot(pt.X-prev.X, pt.Y-prev.Y)
+		prev = pt
+	}
+	return l
+}
+
+// ParamAt returns the parameter t at which the given fraction (0-1)
+// of the curve's arc length has been travelled.
+func (c Curve) ParamAt(fraction float64) float64 {
+	if fraction <= 0 {
+		return 0
+	}
+	if fraction >= 1 {
+		return 1
+	}
+	var lengths [curvesteps + 1]float64
+	prev := c.PointAt(0)
+	for i := 1; i <= curvesteps; i++ {
+		pt := c.PointAt(float64(i) / curvesteps)
+		lengths[i] = lengths[i-1] + math.Hypot(pt.X-prev.X, pt.Y-prev.Y)
+		prev = pt
+	}
+	target := fraction * lengths[curvesteps]
+	for i := 1; i <= curvesteps; i++ {
+		if lengths[i] >= target {
+			seg := lengths[i] - lengths[i-1]
+			f := 0.0
+			if seg > 0 {
+				f = (target - lengths[i-1]) / seg
+			}
+			return (float64(i-1) + f) / curvesteps
+		}
+	}
+	return 1
+}
+
+// PointAtLength returns the point reached after travelling the given fraction (0-1)
+// of the curve's arc length, for evenly spacing markers and labels along a curve.
+func (c Curve) PointAtLength(fraction float64) Point {
+	return c.PointAt(c.ParamAt(fraction))
+}
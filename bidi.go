@@ -0,0 +1,108 @@
+package deckgen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SetDirection sets the text direction of the deck: "rtl" for right-to-left languages such as
+// Arabic and Hebrew, or "ltr" (the default). In right-to-left decks, text and list items are
+// reordered from logical to display order, and the alignment of text is mirrored, so that
+// Text ends at x, and TextEnd starts there.
+//
+// The reordering is a simplification of the Unicode bidirectional algorithm: right-to-left
+// runs are reversed, with mirrored brackets, while embedded left-to-right runs, such as
+// numbers and Latin words, keep their order. Renderers must still shape Arabic letters,
+// and text wrapped by the renderer (TextBlock) may break in the wrong places.
+func (p *DeckGen) SetDirection(dir string) {
+	p.rtl = dir == "rtl"
+}
+
+// isrtl reports whether r is a strong right-to-left character.
+func isrtl(r rune) bool {
+	return unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko)
+}
+
+// isltr reports whether r is a strong left-to-right character, treating digits as such.
+func isltr(r rune) bool {
+	return !isrtl(r) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// mirrored maps brackets to their mirror images, for right-to-left runs.
+var mirrored = map[rune]rune{'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<', '«': '»', '»': '«'}
+
+// bidivisual reorders s, in a right-to-left paragraph, from logical to display order.
+// Lines are reordered separately, and keep their order.
+func bidivisual(s string) string {
+	if !strings.Contains(s, "\n") {
+		return bidiline(s)
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = bidiline(l)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bidiline reorders a line of a right-to-left paragraph from logical to display order.
+func bidiline(s string) string {
+	r := []rune(s)
+	n := len(r)
+	// resolve each character to right-to-left (true) or left-to-right;
+	// neutrals between left-to-right characters are left-to-right
+	rtl := make([]bool, n)
+	for i := 0; i < n; {
+		if !isltr(r[i]) {
+			rtl[i] = true
+			i++
+			continue
+		}
+		j, last := i, i
+		for j < n && !isrtl(r[j]) {
+			if isltr(r[j]) {
+				last = j
+			}
+			j++
+		}
+		for k := i; k < j; k++ {
+			rtl[k] = k > last
+		}
+		i = j
+	}
+	// emit the runs in reverse order, reversing the right-to-left ones
+	out := make([]rune, 0, n)
+	for end := n; end > 0; {
+		start := end - 1
+		for start > 0 && rtl[start-1] == rtl[end-1] {
+			start--
+		}
+		if rtl[start] {
+			for k := end - 1; k >= start; k-- {
+				c := r[k]
+				if m, ok := mirrored[c]; ok {
+					c = m
+				}
+				out = append(out, c)
+			}
+		} else {
+			out = append(out, r[start:end]...)
+		}
+		end = start
+	}
+	return string(out)
+}
+
+// direct applies the text direction to a text element. Code is left as it is.
+func (p *DeckGen) direct(t Text) Text {
+	if !p.rtl || t.Type == "code" {
+		return t
+	}
+	t.Tdata = bidivisual(t.Tdata)
+	switch t.Align {
+	case "", "left", "begin", "start":
+		t.Align = "right"
+	case "right", "end":
+		t.Align = ""
+	}
+	return t
+}
@@ -0,0 +1,34 @@
+package deckgen
+
+import "testing"
+
+func TestBidiVisual(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"hello", "hello"},
+		{"שלום", "םולש"},
+		{"שלום 123", "123 םולש"},
+		{"שלום (עולם)", "(םלוע) םולש"},
+		{"אבג abc def", "abc def גבא"},
+		{"שלום\nעולם", "םולש\nםלוע"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if got := bidivisual(test.in); got != test.want {
+			t.Errorf("bidivisual(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestDirectCode(t *testing.T) {
+	p := NewSlides(nil, 1024, 768)
+	p.SetDirection("rtl")
+	code := Text{Tdata: "x := f(a);"}
+	code.Type = "code"
+	if got := p.direct(code); got.Tdata != code.Tdata || got.Align != "" {
+		t.Errorf("direct(code) = %q aligned %q, want unchanged", got.Tdata, got.Align)
+	}
+	text := Text{Tdata: "שלום"}
+	if got := p.direct(text); got.Tdata != "םולש" || got.Align != "right" {
+		t.Errorf("direct(text) = %q aligned %q", got.Tdata, got.Align)
+	}
+}
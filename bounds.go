@@ -0,0 +1,260 @@
+package deckgen
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Box is a rectangle in canvas percentages; (X, Y) is the lower left corner.
+type Box struct {
+	X, Y, W, H float64
+}
+
+// Right returns the x coordinate of the right edge of the box.
+func (b Box) Right() float64 {
+	return b.X + b.W
+}
+
+// Top returns the y coordinate of the top edge of the box.
+func (b Box) Top() float64 {
+	return b.Y + b.H
+}
+
+// Center returns the center point of the box.
+func (b Box) Center() (float64, float64) {
+	return b.X + b.W/2, b.Y + b.H/2
+}
+
+// Empty reports whether the box has no area.
+func (b Box) Empty() bool {
+	return b.W <= 0 || b.H <= 0
+}
+
+// Overlaps reports whether two boxes intersect.
+func (b Box) Overlaps(o Box) bool {
+	return b.X < o.Right() && o.X < b.Right() && b.Y < o.Top() && o.Y < b.Top()
+}
+
+// Contains reports whether the point (x, y) is inside the box.
+func (b Box) Contains(x, y float64) bool {
+	return x >= b.X && x <= b.Right() && y >= b.Y && y <= b.Top()
+}
+
+// Union returns the smallest box containing both boxes.
+func (b Box) Union(o Box) Box {
+	if b.Empty() {
+		return o
+	}
+	if o.Empty() {
+		return b
+	}
+	x1, y1 := math.Min(b.X, o.X), math.Min(b.Y, o.Y)
+	x2, y2 := math.Max(b.Right(), o.Right()), math.Max(b.Top(), o.Top())
+	return Box{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}
+}
+
+// Inset returns the box shrunk by dx on the left and right, and dy on the top and bottom.
+// Negative values grow the box.
+func (b Box) Inset(dx, dy float64) Box {
+	return Box{X: b.X + dx, Y: b.Y + dy, W: b.W - 2*dx, H: b.H - 2*dy}
+}
+
+// boxpoints returns the box enclosing a set of points.
+func boxpoints(x, y []float64) Box {
+	if len(x) == 0 || len(x) != len(y) {
+		return Box{}
+	}
+	x1, x2, y1, y2 := x[0], x[0], y[0], y[0]
+	for i := 1; i < len(x); i++ {
+		x1, x2 = math.Min(x1, x[i]), math.Max(x2, x[i])
+		y1, y2 = math.Min(y1, y[i]), math.Max(y2, y[i])
+	}
+	return Box{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}
+}
+
+// centerbox returns the box of width w and height h centered at (x, y).
+func centerbox(x, y, w, h float64) Box {
+	return Box{X: x - w/2, Y: y - h/2, W: w, H: h}
+}
+
+// hpct converts a length expressed as a percentage of the canvas width
+// into a percentage of the canvas height.
+func (p *DeckGen) hpct(v float64) float64 {
+	if p.height == 0 {
+		return v
+	}
+	return v * float64(p.width) / float64(p.height)
+}
+
+// wpct converts a length expressed as a percentage of the canvas height
+// into a percentage of the canvas width.
+func (p *DeckGen) wpct(v float64) float64 {
+	if p.width == 0 {
+		return v
+	}
+	return v * float64(p.height) / float64(p.width)
+}
+
+// charwidth is the estimated average character width, as a fraction of the font size.
+func charwidth(font string) float64 {
+	switch font {
+	case "mono":
+		return 0.6
+	case "serif":
+		return 0.5
+	default:
+		return 0.55
+	}
+}
+
+// textwidth measures the width of a string, as a percentage of the canvas width, from font metrics.
+func textwidth(s, font string, size float64) float64 {
+	w := 0.0
+	for _, r := range s {
+		w += runewidth(r, font)
+	}
+	return w * size
+}
+
+// leading returns the line spacing factor, defaulting to the renderer's double spacing.
+func leading(lp float64) float64 {
+	if lp <= 0 {
+		return 2
+	}
+	return lp
+}
+
+// alignx returns the left edge of a span of width w anchored at x with the given alignment.
+func alignx(x, w float64, align string) float64 {
+	switch align {
+	case "center", "middle", "mid", "c":
+		return x - w/2
+	case "end", "right", "e":
+		return x - w
+	}
+	return x
+}
+
+// textbounds estimates the box occupied by a text element.
+func (p *DeckGen) textbounds(t Text) Box {
+	font := t.Font
+	if t.Type == "code" {
+		font = "mono"
+	}
+	lines := strings.Split(t.Tdata, "\n")
+	w := 0.0
+	nlines := 0
+	for _, s := range lines {
+		lw := textwidth(s, font, t.Sp)
+		if t.Wp > 0 && (t.Type == "block" || t.Type == "code") && lw > t.Wp {
+			nlines += int(math.Ceil(lw / t.Wp))
+			lw = t.Wp
+		} else {
+			nlines++
+		}
+		w = math.Max(w, lw)
+	}
+	lh := p.hpct(t.Sp)
+	h := lh + float64(nlines-1)*lh*leading(t.Lp)
+	descent := 0.25 * lh
+	return Box{X: alignx(t.Xp, w, t.Align), Y: t.Yp - h + lh - descent, W: w, H: h + descent}
+}
+
+// listbounds estimates the box occupied by a list.
+func (p *DeckGen) listbounds(l List) Box {
+	w := 0.0
+	for _, li := range l.Li {
+		w = math.Max(w, textwidth(li.ListText, l.Font, l.Sp))
+	}
+	if l.Wp > 0 && w > l.Wp {
+		w = l.Wp
+	}
+	if l.Type == "bullet" || l.Type == "number" {
+		w += l.Sp * 2
+	}
+	n := len(l.Li)
+	if n == 0 {
+		return Box{X: l.Xp, Y: l.Yp}
+	}
+	lh := p.hpct(l.Sp)
+	h := lh + float64(n-1)*lh*leading(l.Lp)
+	descent := 0.25 * lh
+	return Box{X: l.Xp, Y: l.Yp - h + lh - descent, W: w, H: h + descent}
+}
+
+// dimbounds returns the box of a centered dimensioned object.
+func (p *DeckGen) dimbounds(d Dimension) Box {
+	h := d.Hp
+	if d.Hr > 0 {
+		h = p.hpct(d.Wp * d.Hr / 100)
+	}
+	return centerbox(d.Xp, d.Yp, d.Wp, h)
+}
+
+// quadextent returns the minimum and maximum of a quadratic Bezier on one axis.
+func quadextent(a, b, c float64) (float64, float64) {
+	lo, hi := math.Min(a, c), math.Max(a, c)
+	if d := a - 2*b + c; d != 0 {
+		if t := (a - b) / d; t > 0 && t < 1 {
+			v := (1-t)*(1-t)*a + 2*(1-t)*t*b + t*t*c
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	return lo, hi
+}
+
+// parsecoords converts a string of space-separated numbers to a slice.
+func parsecoords(s string) []float64 {
+	f := strings.Fields(s)
+	v := make([]float64, 0, len(f))
+	for _, c := range f {
+		n, err := strconv.ParseFloat(c, 64)
+		if err != nil {
+			continue
+		}
+		v = append(v, n)
+	}
+	return v
+}
+
+// Bounds returns the approximate rectangle, in canvas percentages, occupied by a deck element.
+// Elements may be Text, List, Image, Ellipse, Rect, Line, Curve, Arc, Polygon, or Polyline.
+// Text extents are measured with the font metrics used for wrapping: the Go fonts
+// when built with the gofont tag, and otherwise built-in Helvetica and Times widths
+// for the sans and serif fonts, and fixed widths for mono.
+// Unknown elements return an empty box.
+func (p *DeckGen) Bounds(element interface{}) Box {
+	switch e := element.(type) {
+	case Text:
+		return p.textbounds(e)
+	case List:
+		return p.listbounds(e)
+	case Image:
+		w, h := float64(e.Width), float64(e.Height)
+		if e.Scale > 0 {
+			w, h = w*e.Scale/100, h*e.Scale/100
+		}
+		if p.width == 0 || p.height == 0 {
+			return Box{X: e.Xp, Y: e.Yp}
+		}
+		return centerbox(e.Xp, e.Yp, w/float64(p.width)*100, h/float64(p.height)*100)
+	case Ellipse:
+		return p.dimbounds(e.Dimension)
+	case Rect:
+		return p.dimbounds(e.Dimension)
+	case Arc:
+		return p.dimbounds(e.Dimension)
+	case Line:
+		return boxpoints([]float64{e.Xp1, e.Xp2}, []float64{e.Yp1, e.Yp2})
+	case Curve:
+		x1, x2 := quadextent(e.Xp1, e.Xp2, e.Xp3)
+		y1, y2 := quadextent(e.Yp1, e.Yp2, e.Yp3)
+		return Box{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}
+	case Polygon:
+		return boxpoints(parsecoords(e.XC), parsecoords(e.YC))
+	case Polyline:
+		return boxpoints(parsecoords(e.XC), parsecoords(e.YC))
+	}
+	return Box{}
+}
@@ -0,0 +1,46 @@
+package deckgen
+
+import (
+	"io"
+	"math"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	p := NewSlides(io.Discard, 1000, 1000)
+	text := func(x, y, size, wrap float64, s, font, align, ttype string) Text {
+		t := Text{Wp: wrap, Tdata: s}
+		t.Xp, t.Yp, t.Sp, t.Font, t.Align, t.Type = x, y, size, font, align, ttype
+		return t
+	}
+	list := List{Li: []ListItem{{ListText: "ab"}, {ListText: "ab"}, {ListText: "ab"}}}
+	list.Xp, list.Yp, list.Sp, list.Font, list.Type = 10, 50, 2, "sans", "bullet"
+	image := Image{Width: 200, Height: 100, Scale: 50}
+	image.Xp, image.Yp = 50, 50
+	tests := []struct {
+		name    string
+		element interface{}
+		want    Box
+	}{
+		{"text", text(10, 50, 2, 0, "abcd", "mono", "", ""), Box{X: 10, Y: 49.5, W: 4.8, H: 2.5}},
+		{"centered text", text(10, 50, 2, 0, "abcd", "mono", "center", ""), Box{X: 7.6, Y: 49.5, W: 4.8, H: 2.5}},
+		{"wrapped code", text(10, 50, 2, 2.4, "abcd", "sans", "", "code"), Box{X: 10, Y: 45.5, W: 2.4, H: 6.5}},
+		{"two lines", text(10, 50, 2, 0, "ab\nabcd", "mono", "end", ""), Box{X: 5.2, Y: 45.5, W: 4.8, H: 6.5}},
+		{"list", list, Box{X: 10, Y: 41.5, W: 6.224, H: 10.5}},
+		{"image", image, Box{X: 45, Y: 47.5, W: 10, H: 5}},
+		{"rect", Rect{Dimension{CommonAttr: CommonAttr{Xp: 50, Yp: 50}, Wp: 10, Hp: 20}}, Box{X: 45, Y: 40, W: 10, H: 20}},
+		{"ellipse", Ellipse{Dimension{CommonAttr: CommonAttr{Xp: 50, Yp: 50}, Wp: 10, Hr: 50}}, Box{X: 45, Y: 47.5, W: 10, H: 5}},
+		{"line", Line{Xp1: 10, Yp1: 20, Xp2: 30, Yp2: 5}, Box{X: 10, Y: 5, W: 20, H: 15}},
+		{"curve", Curve{Xp1: 0, Yp1: 0, Xp2: 10, Yp2: 20, Xp3: 20, Yp3: 0}, Box{X: 0, Y: 0, W: 20, H: 10}},
+		{"polygon", Polygon{XC: "10 20 30", YC: "5 15 10"}, Box{X: 10, Y: 5, W: 20, H: 10}},
+		{"polyline", Polyline{XC: "10 20 x 30", YC: "5 15 10"}, Box{X: 10, Y: 5, W: 20, H: 10}},
+		{"unknown", "text", Box{}},
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	for _, test := range tests {
+		b := p.Bounds(test.element)
+		if !near(b.X, test.want.X) || !near(b.Y, test.want.Y) || !near(b.W, test.want.W) || !near(b.H, test.want.H) {
+			t.Errorf("%s: Bounds = %+v, want %+v", test.name, b, test.want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package deckgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SlideBuilder builds a slide with chained calls, for example
+// p.Slide().Bg("black").Fg("white").Title("Results").Bullets(items).Done(), or
+// p.Slide("black", "white").Text(10, 90, "Results", "sans", 5, "").Rect(50, 50, 20, 10, "red").End().
+// Elements are collected and emitted by Done, so colors may be set in any order.
+// Errors from individual calls are accumulated and reported by Done.
+type SlideBuilder struct {
+	p     *DeckGen
+	ctx   SlideContext
+	steps []func()
+	errs  []error
+	done  bool
+}
+
+// BuildError lists the errors accumulated while building a slide.
+type BuildError []error
+
+// Error joins the accumulated errors.
+func (e BuildError) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Slide begins building a slide, with optional background and foreground colors.
+func (p *DeckGen) Slide(colors ...string) *SlideBuilder {
+	b := &SlideBuilder{p: p}
+	if len(colors) > 0 {
+		b.Bg(colors[0])
+	}
+	if len(colors) > 1 {
+		b.Fg(colors[1])
+	}
+	return b
+}
+
+// errorf records an error.
+func (b *SlideBuilder) errorf(format string, args ...interface{}) *SlideBuilder {
+	b.errs = append(b.errs, fmt.Errorf(format, args...))
+	return b
+}
+
+// add records a drawing step.
+func (b *SlideBuilder) add(fn func()) *SlideBuilder {
+	if b.done {
+		return b.errorf("slide already done")
+	}
+	b.steps = append(b.steps, fn)
+	return b
+}
+
+// Bg sets the background color.
+func (b *SlideBuilder) Bg(color string) *SlideBuilder {
+	if color == "" {
+		return b.errorf("Bg: empty color")
+	}
+	b.ctx.Bg = color
+	return b
+}
+
+// Fg sets the foreground color, used by default for text.
+func (b *SlideBuilder) Fg(color string) *SlideBuilder {
+	if color == "" {
+		return b.errorf("Fg: empty color")
+	}
+	b.ctx.Fg = color
+	return b
+}
+
+// Font sets the default font.
+func (b *SlideBuilder) Font(font string) *SlideBuilder {
+	b.ctx.Font = font
+	return b
+}
+
+// Title sets the slide title.
+func (b *SlideBuilder) Title(s string) *SlideBuilder {
+	if b.ctx.Title != "" {
+		return b.errorf("Title: title already set to %q", b.ctx.Title)
+	}
+	if s == "" {
+		return b.errorf("Title: empty title")
+	}
+	b.ctx.Title = s
+	return b
+}
+
+// Bullets adds a bullet list, placed below the title.
+func (b *SlideBuilder) Bullets(items []string) *SlideBuilder {
+	if len(items) == 0 {
+		return b.errorf("Bullets: no items")
+	}
+	return b.add(func() {
+		y := 85.0
+		if b.ctx.Title != "" {
+			y = 75
+		}
+		b.p.List(10, y, 2.5, 0, 80, items, "bullet", "", "")
+	})
+}
+
+// Do adds arbitrary drawing to the slide.
+func (b *SlideBuilder) Do(fn func(*DeckGen)) *SlideBuilder {
+	if fn == nil {
+		return b.errorf("Do: nil function")
+	}
+	return b.add(func() { fn(b.p) })
+}
+
+// Text adds plain text; see DeckGen.Text.
+func (b *SlideBuilder) Text(x, y float64, s, font string, size float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Text(x, y, s, font, size, color, opacity...) })
+}
+
+// TextMid adds centered text; see DeckGen.TextMid.
+func (b *SlideBuilder) TextMid(x, y float64, s, font string, size float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.TextMid(x, y, s, font, size, color, opacity...) })
+}
+
+// TextEnd adds right-justified text; see DeckGen.TextEnd.
+func (b *SlideBuilder) TextEnd(x, y float64, s, font string, size float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.TextEnd(x, y, s, font, size, color, opacity...) })
+}
+
+// TextBlock adds a block of wrapped text; see DeckGen.TextBlock.
+func (b *SlideBuilder) TextBlock(x, y float64, s, font string, size, margin float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.TextBlock(x, y, s, font, size, margin, color, opacity...) })
+}
+
+// Code adds a code block; see DeckGen.Code.
+func (b *SlideBuilder) Code(x, y float64, s string, size, margin float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Code(x, y, s, size, margin, color, opacity...) })
+}
+
+// List adds a list; see DeckGen.List.
+func (b *SlideBuilder) List(x, y, size, spacing, wrap float64, items []string, ltype, font, color string) *SlideBuilder {
+	return b.add(func() { b.p.List(x, y, size, spacing, wrap, items, ltype, font, color) })
+}
+
+// Rect adds a rectangle; see DeckGen.Rect.
+func (b *SlideBuilder) Rect(x, y, w, h float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Rect(x, y, w, h, color, opacity...) })
+}
+
+// Square adds a square; see DeckGen.Square.
+func (b *SlideBuilder) Square(x, y, w float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Square(x, y, w, color, opacity...) })
+}
+
+// Ellipse adds an ellipse; see DeckGen.Ellipse.
+func (b *SlideBuilder) Ellipse(x, y, w, h float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Ellipse(x, y, w, h, color, opacity...) })
+}
+
+// Circle adds a circle; see DeckGen.Circle.
+func (b *SlideBuilder) Circle(x, y, w float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Circle(x, y, w, color, opacity...) })
+}
+
+// Line adds a line; see DeckGen.Line.
+func (b *SlideBuilder) Line(x1, y1, x2, y2, size float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Line(x1, y1, x2, y2, size, color, opacity...) })
+}
+
+// Arc adds an arc; see DeckGen.Arc.
+func (b *SlideBuilder) Arc(x, y, w, h, size, a1, a2 float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Arc(x, y, w, h, size, a1, a2, color, opacity...) })
+}
+
+// Curve adds a quadratic Bézier curve; see DeckGen.Curve.
+func (b *SlideBuilder) Curve(x1, y1, x2, y2, x3, y3, size float64, color string, opacity ...float64) *SlideBuilder {
+	return b.add(func() { b.p.Curve(x1, y1, x2, y2, x3, y3, size, color, opacity...) })
+}
+
+// Polygon adds a polygon; see DeckGen.Polygon.
+func (b *SlideBuilder) Polygon(x, y []float64, color string, opacity ...float64) *SlideBuilder {
+	if len(x) != len(y) {
+		return b.errorf("Polygon: %d x and %d y coordinates", len(x), len(y))
+	}
+	return b.add(func() { b.p.Polygon(x, y, color, opacity...) })
+}
+
+// Polyline adds a polyline; see DeckGen.Polyline.
+func (b *SlideBuilder) Polyline(x, y []float64, size float64, color string, opacity ...float64) *SlideBuilder {
+	if len(x) != len(y) {
+		return b.errorf("Polyline: %d x and %d y coordinates", len(x), len(y))
+	}
+	return b.add(func() { b.p.Polyline(x, y, size, color, opacity...) })
+}
+
+// Image adds an image; see DeckGen.Image.
+func (b *SlideBuilder) Image(x, y float64, w, h int, name, link string) *SlideBuilder {
+	if name == "" {
+		return b.errorf("Image: empty name")
+	}
+	return b.add(func() { b.p.Image(x, y, w, h, name, link) })
+}
+
+// Chart adds a bar chart; see DeckGen.BarChart.
+func (b *SlideBuilder) Chart(region Region, data []NameValue, opts ...ChartOptions) *SlideBuilder {
+	return b.add(func() { b.p.BarChart(region, data, opts...) })
+}
+
+// Err returns the errors accumulated so far, or nil.
+func (b *SlideBuilder) Err() error {
+	if len(b.errs) == 0 {
+		return nil
+	}
+	return BuildError(b.errs)
+}
+
+// Done emits the slide and reports any accumulated errors, with the deck's error, if any
+// (see DeckGen.Err), after emitting it.
+// The slide is emitted even if there were errors, omitting the failed calls.
+func (b *SlideBuilder) Done() error {
+	if b.done {
+		b.errorf("slide already done")
+		return b.Err()
+	}
+	b.done = true
+	b.p.StartSlideContext(b.ctx)
+	for _, step := range b.steps {
+		step()
+	}
+	b.p.EndSlide()
+	if err := b.p.Err(); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b.Err()
+}
+
+// End is a synonym for Done.
+func (b *SlideBuilder) End() error {
+	return b.Done()
+}
@@ -0,0 +1,159 @@
+package deckgen
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// failwriter fails every write.
+type failwriter struct{}
+
+func (failwriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestSlideBuilderDone(t *testing.T) {
+	tests := []struct {
+		name  string
+		w     io.Writer // default: a buffer
+		make  func(p *DeckGen) error
+		errs  []string
+		title bool // the slide is emitted with its title
+	}{
+		{
+			name:  "ok",
+			make:  func(p *DeckGen) error { return p.Slide().Title("Results").Bullets([]string{"one"}).Done() },
+			title: true,
+		},
+		{
+			name:  "builder errors",
+			make:  func(p *DeckGen) error { return p.Slide().Bg("").Title("Results").Title("Again").Done() },
+			errs:  []string{"Bg: empty color", `Title: title already set to "Results"`},
+			title: true,
+		},
+		{
+			name: "done twice",
+			make: func(p *DeckGen) error {
+				b := p.Slide().Title("Results")
+				b.Done()
+				return b.Done()
+			},
+			errs:  []string{"slide already done"},
+			title: true,
+		},
+		{
+			name: "strict",
+			make: func(p *DeckGen) error {
+				p.SetStrict(StrictError)
+				return p.Slide().Title("Results").Rect(150, 50, 10, 10, "red").Done()
+			},
+			errs:  []string{"150"},
+			title: true,
+		},
+		{
+			name: "write error",
+			w:    failwriter{},
+			make: func(p *DeckGen) error {
+				// more than the deck's write buffer
+				return p.Slide().Title("Results").Text(10, 50, strings.Repeat("x", 8192), "sans", 2, "").Done()
+			},
+			errs: []string{"disk full"},
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		w := test.w
+		if w == nil {
+			w = &buf
+		}
+		p := NewSlides(w, 1024, 768)
+		p.StartDeck()
+		err := test.make(p)
+		p.EndDeck()
+		if len(test.errs) == 0 && err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+		if len(test.errs) > 0 && err == nil {
+			t.Errorf("%s: no error, want %q", test.name, test.errs)
+		}
+		for _, e := range test.errs {
+			if err != nil && !strings.Contains(err.Error(), e) {
+				t.Errorf("%s: error %q lacks %q", test.name, err, e)
+			}
+		}
+		if out := buf.String(); test.title && strings.Count(out, ">Results<") != 1 {
+			t.Errorf("%s: slide title emitted %d times, want once", test.name, strings.Count(out, ">Results<"))
+		}
+	}
+}
+
+func TestSlideBuilderElements(t *testing.T) {
+	tests := []struct {
+		name string
+		make func(b *SlideBuilder) *SlideBuilder
+		want []string // in order
+		err  string
+	}{
+		{
+			name: "colors after elements",
+			make: func(b *SlideBuilder) *SlideBuilder {
+				return b.Text(10, 90, "first", "sans", 3, "").Rect(50, 50, 20, 10, "red").Bg("black").Fg("white")
+			},
+			want: []string{`<slide bg="black" fg="white"`, ">first<", `<rect xp="50.00"`},
+		},
+		{
+			name: "shapes",
+			make: func(b *SlideBuilder) *SlideBuilder {
+				return b.Circle(20, 20, 5, "blue").Line(0, 0, 10, 10, 0.2, "gray").Polygon([]float64{1, 2, 3}, []float64{1, 2, 1}, "green")
+			},
+			want: []string{"<ellipse", "<line", "<polygon"},
+		},
+		{
+			name: "polygon coordinates",
+			make: func(b *SlideBuilder) *SlideBuilder {
+				return b.Polygon([]float64{1, 2}, []float64{1}, "green").Square(10, 10, 5, "red")
+			},
+			want: []string{`<rect xp="10.00"`},
+			err:  "Polygon: 2 x and 1 y coordinates",
+		},
+		{
+			name: "polyline coordinates",
+			make: func(b *SlideBuilder) *SlideBuilder { return b.Polyline([]float64{1}, nil, 0.2, "green") },
+			err:  "Polyline: 1 x and 0 y coordinates",
+		},
+		{
+			name: "image name",
+			make: func(b *SlideBuilder) *SlideBuilder { return b.Image(50, 50, 100, 100, "", "") },
+			err:  "Image: empty name",
+		},
+		{
+			name: "nil function",
+			make: func(b *SlideBuilder) *SlideBuilder { return b.Do(nil) },
+			err:  "Do: nil function",
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.StartDeck()
+		err := test.make(p.Slide()).End()
+		p.EndDeck()
+		if (err != nil) != (test.err != "") || err != nil && !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error %v, want %q", test.name, err, test.err)
+		}
+		out := buf.String()
+		if n := strings.Count(out, "<slide"); n != 1 {
+			t.Errorf("%s: %d slides, want 1", test.name, n)
+		}
+		at := 0
+		for _, w := range test.want {
+			i := strings.Index(out[at:], w)
+			if i < 0 {
+				t.Errorf("%s: output lacks %q after offset %d:\n%s", test.name, w, at, out)
+				break
+			}
+			at += i + len(w)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package deckgen
+
+import (
+	"fmt"
+	"time"
+)
+
+// CalendarOptions controls the appearance of generated calendars.
+// Zero values select defaults.
+type CalendarOptions struct {
+	Area        Box    // region occupied by the calendar (default: most of the canvas)
+	Font        string // font for all text (default: the theme's text font)
+	Color       string // color of day numbers and grid lines (default: the theme's foreground)
+	Accent      string // color of the title and weekday names (default: maroon)
+	EventColor  string // color of event annotations and marked days (default: the theme's accent)
+	StartMonday bool   // begin weeks on Monday instead of Sunday
+}
+
+// calendardefaults fills in the unset options.
+func (p *DeckGen) calendardefaults(opts CalendarOptions) CalendarOptions {
+	t := p.themeof(nil)
+	if opts.Area.Empty() {
+		opts.Area = Box{X: 5, Y: 5, W: 90, H: 85}
+	}
+	if opts.Font == "" {
+		opts.Font = t.Font
+	}
+	if opts.Color == "" {
+		opts.Color = t.Fg
+	}
+	if opts.Accent == "" {
+		opts.Accent = "maroon"
+	}
+	if opts.EventColor == "" {
+		opts.EventColor = t.Accent
+	}
+	return opts
+}
+
+// weekdaynames returns the abbreviated day names in display order.
+func weekdaynames(monday bool, n int) []string {
+	names := make([]string, 7)
+	for i := 0; i < 7; i++ {
+		d := time.Weekday(i)
+		if monday {
+			d = time.Weekday((i + 1) % 7)
+		}
+		names[i] = d.String()[:n]
+	}
+	return names
+}
+
+// firstcolumn returns the grid column of the first day of the month.
+func firstcolumn(year, month int, monday bool) int {
+	col := int(time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).Weekday())
+	if monday {
+		col = (col + 6) % 7
+	}
+	return col
+}
+
+// daysin returns the number of days in a month.
+func daysin(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Calendar lays out a month grid on the current slide, with day numbers
+// and event annotations keyed by day of the month.
+func (p *DeckGen) Calendar(year, month int, events map[int]string, opts CalendarOptions) {
+	opts = p.calendardefaults(opts)
+	a := opts.Area
+	title := fmt.Sprintf("%s %d", time.Month(month), year)
+	titlesize := a.W / 30
+	p.TextMid(a.X+a.W/2, a.Top()-p.hpct(titlesize), title, opts.Font, titlesize, opts.Accent)
+
+	headsize := titlesize / 2
+	heady := a.Top() - p.hpct(titlesize)*2.2
+	cw := a.W / 7
+	for i, name := range weekdaynames(opts.StartMonday, 3) {
+		p.TextMid(a.X+cw*(float64(i)+0.5), heady, name, opts.Font, headsize, opts.Accent)
+	}
+
+	gridtop := heady - p.hpct(headsize)
+	ch := (gridtop - a.Y) / 6
+	for r := 0; r <= 6; r++ {
+		y := gridtop - float64(r)*ch
+		p.Line(a.X, y, a.Right(), y, 0.1, opts.Color, 40)
+	}
+	for c := 0; c <= 7; c++ {
+		x := a.X + float64(c)*cw
+		p.Line(x, gridtop, x, a.Y, 0.1, opts.Color, 40)
+	}
+
+	daysize := headsize * 0.9
+	eventsize := daysize * 0.75
+	col := firstcolumn(year, month, opts.StartMonday)
+	for day := 1; day <= daysin(year, month); day++ {
+		row := (col + day - 1) / 7
+		c := (col + day - 1) % 7
+		x := a.X + float64(c)*cw
+		y := gridtop - float64(row)*ch
+		ev, marked := events[day]
+		if marked {
+			p.Rect(x+cw/2, y-ch/2, cw, ch, opts.EventColor, 15)
+		}
+		p.Text(x+cw*0.06, y-p.hpct(daysize)*1.4, fmt.Sprintf("%d", day), opts.Font, daysize, opts.Color)
+		if marked && ev != "" {
+			p.TextBlock(x+cw*0.06, y-p.hpct(daysize)*3, ev, opts.Font, eventsize, cw*0.88, opts.EventColor)
+		}
+	}
+}
+
+// YearCalendar lays out an overview of all twelve months of a year on the
+// current slide, in a four by three grid.
+func (p *DeckGen) YearCalendar(year int, opts CalendarOptions) {
+	opts = p.calendardefaults(opts)
+	a := opts.Area
+	titlesize := a.W / 30
+	p.TextMid(a.X+a.W/2, a.Top()-p.hpct(titlesize), fmt.Sprintf("%d", year), opts.Font, titlesize, opts.Accent)
+
+	top := a.Top() - p.hpct(titlesize)*2
+	mw := a.W / 4
+	mh := (top - a.Y) / 3
+	cw := mw * 0.9 / 7
+	size := cw * 0.35
+	for m := 1; m <= 12; m++ {
+		mx := a.X + float64((m-1)%4)*mw + mw*0.05
+		my := top - float64((m-1)/4)*mh
+		p.Text(mx, my-p.hpct(size)*1.5, time.Month(m).String(), opts.Font, size*1.3, opts.Accent)
+		rh := (mh - p.hpct(size)*5) / 7
+		hy := my - p.hpct(size)*3.5
+		for i, name := range weekdaynames(opts.StartMonday, 1) {
+			p.TextMid(mx+cw*(float64(i)+0.5), hy, name, opts.Font, size, opts.EventColor)
+		}
+		col := firstcolumn(year, m, opts.StartMonday)
+		for day := 1; day <= daysin(year, m); day++ {
+			row := (col + day - 1) / 7
+			c := (col + day - 1) % 7
+			p.TextMid(mx+cw*(float64(c)+0.5), hy-float64(row+1)*rh, fmt.Sprintf("%d", day), opts.Font, size, opts.Color)
+		}
+	}
+}
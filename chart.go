@@ -0,0 +1,203 @@
+package deckgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// Region is the area of the canvas occupied by a chart.
+type Region = Box
+
+// NameValue is a named value of a chart, with an optional color.
+type NameValue struct {
+	Name  string
+	Value float64
+	Color string // bar color (default: the chart color)
+}
+
+// ChartOptions are optional settings of charts. Zero values select defaults.
+type ChartOptions struct {
+	Color    string  // bar color (default: theme accent)
+	Font     string  // label font (default: theme font)
+	Size     float64 // label size (default: 1.5)
+	Format   string  // format of values, for example "%.1f%%" (default: compact)
+	NoValues bool    // omit the values at the bar ends
+	Max      float64 // value of the full scale (default: the largest value)
+}
+
+// chartdefaults fills in the unset options, from the theme t where applicable.
+func chartdefaults(o []ChartOptions, t Theme) ChartOptions {
+	var c ChartOptions
+	if len(o) > 0 {
+		c = o[0]
+	}
+	if c.Color == "" {
+		c.Color = t.Accent
+	}
+	if c.Font == "" {
+		c.Font = t.Font
+	}
+	if c.Size == 0 {
+		c.Size = 1.5
+	}
+	return c
+}
+
+// format formats a chart value.
+func (c ChartOptions) format(v float64) string {
+	if c.Format == "" {
+		return statfmt(v)
+	}
+	return fmt.Sprintf(c.Format, v)
+}
+
+// scale returns the range of a chart of data: from 0, or the smallest negative value,
+// to the full scale value.
+func (c ChartOptions) scale(data []NameValue) (float64, float64) {
+	lo, hi := 0.0, c.Max
+	for _, d := range data {
+		lo = math.Min(lo, d.Value)
+		if c.Max == 0 {
+			hi = math.Max(hi, d.Value)
+		}
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// BarChart draws a vertical bar chart of data within region: a bar for each value, from
+// a zero axis, with the names below the bars and the values at their ends. Negative values
+// extend below the axis.
+func (p *DeckGen) BarChart(region Region, data []NameValue, opts ...ChartOptions) {
+	n := len(data)
+	if n == 0 {
+		return
+	}
+	t := p.themeof(nil)
+	c := chartdefaults(opts, t)
+	font := p.fontof(c.Font)
+	lh := p.hpct(c.Size)
+	plot := Box{X: region.X, Y: region.Y + lh*2, W: region.W, H: region.H - lh*2}
+	if !c.NoValues {
+		plot.H -= lh * 1.5
+	}
+	lo, hi := c.scale(data)
+	if lo < 0 && !c.NoValues {
+		plot.Y += lh * 1.5
+		plot.H -= lh * 1.5
+	}
+	ypos := func(v float64) float64 { return plot.Y + plot.H*(v-lo)/(hi-lo) }
+	y0 := ypos(0)
+	bw := plot.W / float64(n)
+	for i, d := range data {
+		cx := plot.X + bw*(float64(i)+0.5)
+		color := d.Color
+		if color == "" {
+			color = c.Color
+		}
+		y := ypos(math.Max(lo, math.Min(d.Value, hi)))
+		if h := math.Abs(y - y0); h > 0 {
+			p.Rect(cx, (y+y0)/2, bw*0.7, h, color)
+		}
+		size := fitsize(d.Name, font, c.Size, bw*0.95)
+		p.TextMid(cx, region.Y+lh*0.5, d.Name, c.Font, size, t.Fg)
+		if !c.NoValues {
+			vy := y + lh*0.5
+			if d.Value < 0 {
+				vy = y - lh*1.3
+			}
+			v := c.format(d.Value)
+			p.TextMid(cx, vy, v, c.Font, fitsize(v, font, c.Size*0.9, bw*0.95), t.Muted)
+		}
+	}
+	p.Line(plot.X, y0, plot.Right(), y0, 0.15, t.Muted)
+	p.Line(plot.X, plot.Y, plot.X, plot.Top(), 0.1, t.Muted)
+}
+
+// truncate shortens s, with an ellipsis, to fit within width.
+func truncate(s, font string, size, width float64) string {
+	if textwidth(s, font, size) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && textwidth(string(r)+"…", font, size) > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
+
+// HBarChart draws a horizontal bar chart of data within region, suited to long names, such as
+// ranked lists and survey results: the names are set at the left, wrapped, and if still too
+// long, truncated, with a bar for each value to the right, from a zero axis, and the values
+// at the bar ends.
+func (p *DeckGen) HBarChart(region Region, data []NameValue, opts ...ChartOptions) {
+	n := len(data)
+	if n == 0 {
+		return
+	}
+	t := p.themeof(nil)
+	c := chartdefaults(opts, t)
+	font := p.fontof(c.Font)
+	rh := region.H / float64(n)
+	size := math.Min(c.Size, rh/p.hpct(1)*0.5)
+	ls := p.hpct(size * 1.2)
+	maxlines := int(rh * 0.9 / ls)
+	if maxlines < 1 {
+		maxlines = 1
+	}
+	labelw := 0.0
+	for _, d := range data {
+		labelw = math.Max(labelw, textwidth(d.Name, font, size))
+	}
+	labelw = math.Min(labelw+1, region.W*0.35)
+	plot := Box{X: region.X + labelw + 1, Y: region.Y, W: region.W - labelw - 1, H: region.H}
+	lo, hi := c.scale(data)
+	if !c.NoValues {
+		// room for the values at the bar ends
+		vw := 0.0
+		for _, d := range data {
+			vw = math.Max(vw, textwidth(c.format(d.Value), font, size*0.9))
+		}
+		if hi > 0 {
+			plot.W -= vw + 1
+		}
+		if lo < 0 {
+			plot.X += vw + 1
+			plot.W -= vw + 1
+		}
+	}
+	xpos := func(v float64) float64 { return plot.X + plot.W*(v-lo)/(hi-lo) }
+	x0 := xpos(0)
+	for i, d := range data {
+		cy := region.Top() - rh*(float64(i)+0.5)
+		lines := wraplines(d.Name, font, size, labelw-1)
+		if len(lines) > maxlines {
+			lines = lines[:maxlines]
+			lines[maxlines-1] = truncate(lines[maxlines-1]+"…", font, size, labelw-1)
+		}
+		y := cy + ls*float64(len(lines)-1)/2 - p.hpct(size)/3
+		for _, l := range lines {
+			p.TextEnd(region.X+labelw, y, l, c.Font, size, t.Fg)
+			y -= ls
+		}
+		color := d.Color
+		if color == "" {
+			color = c.Color
+		}
+		x := xpos(math.Max(lo, math.Min(d.Value, hi)))
+		if w := math.Abs(x - x0); w > 0 {
+			p.Rect((x+x0)/2, cy, w, math.Min(rh*0.7, p.hpct(4)), color)
+		}
+		if !c.NoValues {
+			v := c.format(d.Value)
+			if d.Value < 0 {
+				p.TextEnd(x-0.5, cy-p.hpct(size)/3, v, c.Font, size*0.9, t.Muted)
+			} else {
+				p.Text(x+0.5, cy-p.hpct(size)/3, v, c.Font, size*0.9, t.Muted)
+			}
+		}
+	}
+	p.Line(x0, region.Y, x0, region.Top(), 0.15, t.Muted)
+}
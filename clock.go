@@ -0,0 +1,95 @@
+package deckgen
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// polar returns the point at distance r (in canvas width percentages) and angle deg from (x, y),
+// correcting the vertical distance for the canvas aspect ratio.
+func (p *DeckGen) polar(x, y, r, deg float64) (float64, float64) {
+	s, c := math.Sincos(deg * math.Pi / 180)
+	return x + r*c, y + p.hpct(r*s)
+}
+
+// clockangle converts a position on a dial of n divisions to degrees, with 0 at twelve o'clock.
+func clockangle(v, n float64) float64 {
+	return 90 - v/n*360
+}
+
+// ClockFace draws an analog clock centered at (x, y) with diameter size, showing time t.
+// The face uses the optional colors for the dial and hands (default: the theme's foreground),
+// and the second hand.
+func (p *DeckGen) ClockFace(x, y, size float64, t time.Time, colors ...string) {
+	th := p.themeof(nil)
+	face, accent := th.Fg, "red"
+	if len(colors) > 0 {
+		face = colors[0]
+	}
+	if len(colors) > 1 {
+		accent = colors[1]
+	}
+	r := size / 2
+	p.Arc(x, y, size, p.hpct(size), size/60, 0, 360, face)
+	for i := 0; i < 60; i++ {
+		a := clockangle(float64(i), 60)
+		inner := r * 0.93
+		thick := size / 300
+		if i%5 == 0 {
+			inner, thick = r*0.85, size/120
+		}
+		x1, y1 := p.polar(x, y, inner, a)
+		x2, y2 := p.polar(x, y, r*0.97, a)
+		p.Line(x1, y1, x2, y2, thick, face)
+	}
+	ts := size / 14
+	for h := 1; h <= 12; h++ {
+		nx, ny := p.polar(x, y, r*0.7, clockangle(float64(h), 12))
+		p.TextMid(nx, ny-p.hpct(ts)/3, fmt.Sprintf("%d", h), th.Font, ts, face)
+	}
+	hours := float64(t.Hour()%12) + float64(t.Minute())/60
+	minutes := float64(t.Minute()) + float64(t.Second())/60
+	hx, hy := p.polar(x, y, r*0.5, clockangle(hours, 12))
+	mx, my := p.polar(x, y, r*0.8, clockangle(minutes, 60))
+	sx, sy := p.polar(x, y, r*0.85, clockangle(float64(t.Second()), 60))
+	p.Line(x, y, hx, hy, size/40, face)
+	p.Line(x, y, mx, my, size/60, face)
+	p.Line(x, y, sx, sy, size/200, accent)
+	p.Circle(x, y, size/25, accent)
+}
+
+// Countdown generates one slide for each minute from minutes down to zero,
+// showing the time remaining as a shrinking ring, for workshop timers.
+// Optional colors are the background, foreground, and ring colors (default: the theme's
+// background, foreground and accent).
+func (p *DeckGen) Countdown(minutes int, colors ...string) {
+	t := p.themeof(nil)
+	bg, fg, ring := t.Bg, t.Fg, t.Accent
+	if len(colors) > 0 {
+		bg = colors[0]
+	}
+	if len(colors) > 1 {
+		fg = colors[1]
+	}
+	if len(colors) > 2 {
+		ring = colors[2]
+	}
+	size := 40.0
+	for m := minutes; m >= 0; m-- {
+		p.StartSlide(bg, fg)
+		p.Arc(50, 50, size, p.hpct(size), size/20, 0, 360, fg, 10)
+		if m > 0 && minutes > 0 {
+			frac := float64(m) / float64(minutes)
+			p.Arc(50, 50, size, p.hpct(size), size/20, 90, 90+360*frac, ring)
+		}
+		label := fmt.Sprintf("%d", m)
+		p.TextMid(50, 50-p.hpct(size/8)/2, label, t.Font, size/4, fg)
+		unit := "minutes"
+		if m == 1 {
+			unit = "minute"
+		}
+		p.TextMid(50, 50-p.hpct(size/4), unit, t.Font, size/16, fg, 60)
+		p.EndSlide()
+	}
+}
@@ -0,0 +1,148 @@
+package deckgen
+
+import "strings"
+
+// DiffOp is the kind of a diff line.
+type DiffOp int
+
+// Diff line kinds
+const (
+	DiffSame DiffOp = iota
+	DiffRemoved
+	DiffAdded
+)
+
+// DiffLine is a line of a line-oriented diff.
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// LineDiff computes the difference between two texts, line by line,
+// using the longest common subsequence.
+func LineDiff(before, after string) []DiffLine {
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var diff []DiffLine
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			diff = append(diff, DiffLine{DiffSame, a[i]})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] > lcs[i+1][j]):
+			diff = append(diff, DiffLine{DiffAdded, b[j]})
+			j++
+		default:
+			diff = append(diff, DiffLine{DiffRemoved, a[i]})
+			i++
+		}
+	}
+	return diff
+}
+
+// changedspan returns the rune offsets of the part of s that differs from other,
+// after removing their common prefix and suffix.
+func changedspan(s, other string) (int, int) {
+	a, b := []rune(s), []rune(other)
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	return pre, len(a) - suf
+}
+
+// code diff layout
+const (
+	diffsize    = 1.2
+	difftop     = 82
+	diffbottom  = 5
+	diffleft    = 5
+	diffwidth   = 90
+	diffleading = 1.7
+)
+
+// CodeDiff generates slides showing the line difference between two versions of
+// source code, with removed lines on a red background and added lines on green.
+// The lang names the language in the slide titles. When intraline is set,
+// the changed characters of a removed line followed by an added line are highlighted.
+func (p *DeckGen) CodeDiff(before, after, lang string, intraline bool) {
+	diff := LineDiff(before, after)
+	lh := p.hpct(diffsize) * diffleading
+	perslide := int((difftop - diffbottom) / lh)
+	if perslide < 1 {
+		perslide = 1
+	}
+	cw := diffsize * charwidth("mono")
+	t := p.themeof(nil)
+	title := "Changes"
+	if lang != "" {
+		title = lang + " changes"
+	}
+	for start := 0; start < len(diff); start += perslide {
+		end := start + perslide
+		if end > len(diff) {
+			end = len(diff)
+		}
+		heading := title
+		if start > 0 {
+			heading += " (continued)"
+		}
+		p.reportslide(heading)
+		for k := start; k < end; k++ {
+			d := diff[k]
+			y := difftop - lh*float64(k-start)
+			prefix, bg, hl := "  ", "", ""
+			switch d.Op {
+			case DiffRemoved:
+				prefix, bg, hl = "- ", "rgb(255,235,233)", "rgb(255,170,165)"
+			case DiffAdded:
+				prefix, bg, hl = "+ ", "rgb(230,255,236)", "rgb(172,242,189)"
+			}
+			if bg != "" {
+				p.Rect(diffleft+diffwidth/2, y+lh*0.3, diffwidth, lh, bg)
+			}
+			if intraline {
+				var other string
+				paired := false
+				if d.Op == DiffRemoved && k+1 < len(diff) && diff[k+1].Op == DiffAdded {
+					other, paired = diff[k+1].Text, true
+				}
+				if d.Op == DiffAdded && k > 0 && diff[k-1].Op == DiffRemoved {
+					other, paired = diff[k-1].Text, true
+				}
+				if paired {
+					s, e := changedspan(d.Text, other)
+					if e > s {
+						x1 := diffleft + 0.5 + cw*float64(s+2)
+						w := cw * float64(e-s)
+						p.Rect(x1+w/2, y+lh*0.3, w, lh, hl)
+					}
+				}
+			}
+			p.Text(diffleft+0.5, y, prefix+d.Text, t.Mono, diffsize, t.Fg)
+		}
+		p.EndSlide()
+	}
+}
@@ -0,0 +1,114 @@
+package deckgen
+
+// SlideContext holds per-slide settings consulted by all element calls:
+// elements with an empty font or color, or a zero text size, use the context's defaults.
+// The context is cleared at the end of the slide.
+type SlideContext struct {
+	Title     string  // title drawn at the top of the slide
+	Bg, Fg    string  // slide background and foreground colors
+	Font      string  // default text font
+	TitleFont string  // title font (default: Font, or sans)
+	Color     string  // default color of text and shapes (default: Fg)
+	Size      float64 // default text size
+}
+
+// StartSlideContext begins a slide using the context's colors, makes the context
+// current until EndSlide, and draws the title, if any.
+func (p *DeckGen) StartSlideContext(c SlideContext) {
+	switch {
+	case c.Bg != "" && c.Fg != "":
+		p.StartSlide(c.Bg, c.Fg)
+	case c.Bg != "":
+		p.StartSlide(c.Bg)
+	default:
+		p.StartSlide()
+	}
+	if c.Color == "" {
+		c.Color = c.Fg
+	}
+	p.ctx = c
+	if c.Title != "" {
+		font := c.TitleFont
+		if font == "" {
+			font = c.Font
+		}
+		if font == "" {
+			font = p.fontof("")
+		}
+		if font == "" {
+			font = "sans"
+		}
+		p.TextMid(50, reporttitley, c.Title, font, reporttitlesize, c.Color)
+	}
+}
+
+// Context returns the current slide context.
+func (p *DeckGen) Context() SlideContext {
+	return p.ctx
+}
+
+// SetContext replaces the current slide context.
+func (p *DeckGen) SetContext(c SlideContext) {
+	p.ctx = c
+}
+
+// fontof resolves a font, falling back to the slide context and the deck default,
+// and replacing registered aliases.
+func (p *DeckGen) fontof(font string) string {
+	if font == "" {
+		font = p.ctx.Font
+	}
+	if font == "" {
+		font = p.deffont
+	}
+	if name, ok := p.fonts[font]; ok {
+		return name
+	}
+	return font
+}
+
+// colorof resolves a color, falling back to the slide context and the deck default.
+func (p *DeckGen) colorof(color string) string {
+	if color != "" {
+		return color
+	}
+	if p.ctx.Color != "" {
+		return p.ctx.Color
+	}
+	return p.defcolor
+}
+
+// widthof resolves a line width, falling back to the deck default.
+func (p *DeckGen) widthof(width float64) float64 {
+	if width != 0 {
+		return width
+	}
+	return p.deflinewidth
+}
+
+// defaultopacity returns the opacity of elements given none: the deck default, or 100.
+func (p *DeckGen) defaultopacity() float64 {
+	if p.defopacity != 0 {
+		return p.defopacity
+	}
+	return 100
+}
+
+// SetDefaults sets deck-wide defaults for the font and color of elements given none,
+// the opacity of elements whose opacity is omitted, and the width of lines, arcs and curves
+// given a zero width. Empty and zero values leave the built-in defaults. Slide contexts
+// take precedence over these defaults.
+func (p *DeckGen) SetDefaults(font, textColor string, opacity, lineWidth float64) {
+	p.deffont = font
+	p.defcolor = textColor
+	p.defopacity = opacity
+	p.deflinewidth = lineWidth
+}
+
+// sizeof resolves a text size, falling back to the slide context.
+func (p *DeckGen) sizeof(size float64) float64 {
+	if size != 0 {
+		return size
+	}
+	return p.ctx.Size
+}
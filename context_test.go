@@ -0,0 +1,33 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestElementDefaults(t *testing.T) {
+	x, y := []float64{10, 20, 15}, []float64{10, 10, 20}
+	tests := []struct {
+		name string
+		make func(p *DeckGen)
+		want string
+	}{
+		{"code", func(p *DeckGen) { p.Code(10, 50, "x := 1", 2, 20, "black") }, `font="courier"`},
+		{"code spacing", func(p *DeckGen) { p.CodeSpacing(10, 50, "x := 1", 2, 20, 1.5, "black") }, `font="courier"`},
+		{"polygon", func(p *DeckGen) { p.Polygon(x, y, "red") }, `opacity="40.00"`},
+		{"polyline", func(p *DeckGen) { p.Polyline(x, y, 0.2, "red") }, `opacity="40.00"`},
+		{"polygon opacity", func(p *DeckGen) { p.Polygon(x, y, "red", 70) }, `opacity="70.00"`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.SetTheme(Theme{Mono: "courier"})
+		p.SetDefaults("serif", "navy", 40, 0)
+		test.make(p)
+		p.Flush()
+		if out := buf.String(); !strings.Contains(out, test.want) {
+			t.Errorf("%s: %s has no %s", test.name, out, test.want)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package deckgen
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Series is a named time series.
+type Series struct {
+	Name   string
+	Times  []time.Time
+	Values []float64
+}
+
+// DataSource runs a query and returns the resulting time series.
+type DataSource interface {
+	Query(ctx context.Context, query string) ([]Series, error)
+}
+
+// InfluxSource queries an InfluxDB 2.x server with Flux.
+type InfluxSource struct {
+	URL    string // server address, for example http://localhost:8086
+	Org    string
+	Token  string
+	Client *http.Client // http.DefaultClient if nil
+}
+
+// Query runs a Flux query, returning one series for each result table.
+func (s InfluxSource) Query(ctx context.Context, query string) ([]Series, error) {
+	u := strings.TrimSuffix(s.URL, "/") + "/api/v2/query?org=" + url.QueryEscape(s.Org)
+	req, err := http.NewRequestWithContext(ctx, "POST", u, strings.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.flux")
+	req.Header.Set("Accept", "application/csv")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Token "+s.Token)
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("influx query: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return parsefluxcsv(resp.Body)
+}
+
+// fluxheader reports whether a record of Flux CSV is the header row of a table.
+func fluxheader(rec []string) bool {
+	var result, table bool
+	for _, c := range rec {
+		result = result || c == "result"
+		table = table || c == "table"
+	}
+	return result && table
+}
+
+// parsefluxcsv reads the CSV produced by a Flux query, grouping rows by result table.
+func parsefluxcsv(r io.Reader) ([]Series, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	var series []Series
+	index := map[string]int{}
+	var cols map[string]int
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) == 0 || strings.HasPrefix(rec[0], "#") {
+			continue // annotations
+		}
+		// each result table begins with a header row, naming the result and table columns
+		if fluxheader(rec) {
+			cols = map[string]int{}
+			for i, c := range rec {
+				cols[c] = i
+			}
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(rec) {
+				return rec[i]
+			}
+			return ""
+		}
+		t, err := time.Parse(time.RFC3339Nano, field("_time"))
+		if err != nil {
+			continue
+		}
+		v, err := strconv.ParseFloat(field("_value"), 64)
+		if err != nil {
+			continue
+		}
+		name := field("_measurement")
+		if f := field("_field"); f != "" {
+			name += "." + f
+		}
+		key := field("result") + "/" + field("table")
+		i, ok := index[key]
+		if !ok {
+			i = len(series)
+			index[key] = i
+			series = append(series, Series{Name: name})
+		}
+		series[i].Times = append(series[i].Times, t)
+		series[i].Values = append(series[i].Values, v)
+	}
+	return series, nil
+}
+
+// SQLSource queries a SQL database, such as TimescaleDB through a PostgreSQL driver
+// registered by the caller. Queries return rows of (time, value) or (time, name, value).
+type SQLSource struct {
+	DB *sql.DB
+}
+
+// Query runs a SQL query, returning one series for each distinct name.
+func (s SQLSource) Query(ctx context.Context, query string) ([]Series, error) {
+	rows, err := s.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) != 2 && len(cols) != 3 {
+		return nil, fmt.Errorf("query returns %d columns, need (time, value) or (time, name, value)", len(cols))
+	}
+	var series []Series
+	index := map[string]int{}
+	for rows.Next() {
+		var t time.Time
+		var name string
+		var v float64
+		if len(cols) == 2 {
+			name = cols[1]
+			err = rows.Scan(&t, &v)
+		} else {
+			err = rows.Scan(&t, &name, &v)
+		}
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(series)
+			index[name] = i
+			series = append(series, Series{Name: name})
+		}
+		series[i].Times = append(series[i].Times, t)
+		series[i].Values = append(series[i].Values, v)
+	}
+	return series, rows.Err()
+}
+
+// Panel is a titled chart on a dashboard, drawn from the results of a query.
+type Panel struct {
+	Title string
+	Query string
+	Unit  string
+}
+
+// Dashboard is a multi-panel deck regenerated from a data source.
+type Dashboard struct {
+	Source        DataSource
+	Title         string
+	Panels        []Panel
+	Columns       int   // panels per row (default: 2)
+	Width, Height int   // canvas size (default: 1920x1080)
+	Theme         Theme // colors and fonts (default: light text on black)
+}
+
+// errnosource is the error of a dashboard without a data source.
+var errnosource = errors.New("dashboard: no data source")
+
+// dashboardtheme is the dark theme of dashboards, under the theme given.
+var dashboardtheme = Theme{Bg: "black", Fg: "white"}
+
+// seriescolors are the line colors of successive series in a panel.
+var seriescolors = []string{"steelblue", "orangered", "seagreen", "goldenrod", "purple", "gray"}
+
+// Generate runs every panel query and writes the dashboard deck to w.
+// A panel whose query fails shows the error instead of a chart.
+func (d *Dashboard) Generate(ctx context.Context, w io.Writer) error {
+	if d.Source == nil {
+		return errnosource
+	}
+	width, height := d.Width, d.Height
+	if width == 0 || height == 0 {
+		width, height = 1920, 1080
+	}
+	cols := d.Columns
+	if cols <= 0 {
+		cols = 2
+	}
+	p := NewSlides(w, width, height)
+	p.SetTheme(d.Theme.merge(dashboardtheme))
+	t := p.themeof(nil)
+	p.StartDeck()
+	p.reportslide("")
+	title := d.Title
+	if title == "" {
+		title = "Dashboard"
+	}
+	p.Text(3, 94, title, t.TitleFont, 2.5, t.Fg)
+	p.TextEnd(97, 94, time.Now().Format("2006-01-02 15:04:05"), t.Font, 1.2, t.Muted)
+	rows := (len(d.Panels) + cols - 1) / cols
+	area := Box{X: 2, Y: 2, W: 96, H: 88}
+	pw := area.W / float64(cols)
+	ph := area.H / math.Max(1, float64(rows))
+	for i, panel := range d.Panels {
+		b := Box{X: area.X + pw*float64(i%cols), Y: area.Top() - ph*float64(i/cols+1), W: pw, H: ph}.Inset(0.5, p.hpct(0.5))
+		series, err := d.Source.Query(ctx, panel.Query)
+		if err == nil {
+			err = checkseries(series)
+		}
+		p.chartpanel(b, panel, series, err)
+	}
+	p.EndSlide()
+	p.EndDeck()
+	return p.Err()
+}
+
+// checkseries reports an error if a series does not have a time for each value.
+func checkseries(series []Series) error {
+	for _, s := range series {
+		if len(s.Times) != len(s.Values) {
+			return fmt.Errorf("series %s: %d times for %d values", s.Name, len(s.Times), len(s.Values))
+		}
+	}
+	return nil
+}
+
+// chartpanel draws one dashboard panel.
+func (p *DeckGen) chartpanel(b Box, panel Panel, series []Series, err error) {
+	cx, cy := b.Center()
+	t := p.themeof(nil)
+	p.Rect(cx, cy, b.W, b.H, t.Muted, 20)
+	p.Text(b.X+1, b.Top()-p.hpct(2), panel.Title, t.Font, 1.4, t.Fg)
+	if err != nil {
+		p.TextBlock(b.X+1, cy, err.Error(), t.Mono, 1, b.W-2, "orangered")
+		return
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	var t0, t1 time.Time
+	for _, s := range series {
+		for i, v := range s.Values {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+			if ti := s.Times[i]; t0.IsZero() || ti.Before(t0) {
+				t0 = ti
+			}
+			if ti := s.Times[i]; ti.After(t1) {
+				t1 = ti
+			}
+		}
+	}
+	if math.IsInf(lo, 1) {
+		p.TextMid(cx, cy, "no data", t.Font, 1.2, t.Muted)
+		return
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+	span := t1.Sub(t0).Seconds()
+	if span == 0 {
+		span = 1
+	}
+	plot := Box{X: b.X + 6, Y: b.Y + p.hpct(3), W: b.W - 8, H: b.H - p.hpct(8)}
+	p.TextEnd(plot.X-0.5, plot.Top()-p.hpct(0.8), statfmt(hi)+panel.Unit, t.Font, 0.9, t.Muted)
+	p.TextEnd(plot.X-0.5, plot.Y, statfmt(lo)+panel.Unit, t.Font, 0.9, t.Muted)
+	p.Line(plot.X, plot.Y, plot.Right(), plot.Y, 0.1, t.Muted)
+	for i, s := range series {
+		n := len(s.Values)
+		if n == 0 {
+			continue
+		}
+		x := make([]float64, n)
+		y := make([]float64, n)
+		for j, v := range s.Values {
+			x[j] = plot.X + plot.W*s.Times[j].Sub(t0).Seconds()/span
+			y[j] = plot.Y + plot.H*(v-lo)/(hi-lo)
+		}
+		color := seriescolors[i%len(seriescolors)]
+		if i%len(seriescolors) == 0 {
+			color = t.Accent
+		}
+		if n >= 3 {
+			p.Polyline(x, y, 0.2, color, 100)
+		} else if n == 2 {
+			p.Line(x[0], y[0], x[1], y[1], 0.2, color)
+		}
+		if i == 0 {
+			p.TextEnd(b.Right()-1, b.Top()-p.hpct(2), statfmt(s.Values[n-1])+panel.Unit, t.Font, 1.6, color)
+		}
+	}
+}
+
+// Run regenerates the dashboard deck at path immediately and then at every interval,
+// until the context is cancelled. Each deck is written to a temporary file and renamed,
+// so viewers never see a partial deck.
+func (d *Dashboard) Run(ctx context.Context, path string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("dashboard: invalid interval %v", interval)
+	}
+	if d.Source == nil {
+		return errnosource
+	}
+	write := func() error {
+		var buf bytes.Buffer
+		if err := d.Generate(ctx, &buf); err != nil {
+			return err
+		}
+		tmp, err := os.CreateTemp(filepath.Dir(path), ".dashboard-*")
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Write(buf.Bytes()); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		return os.Rename(tmp.Name(), path)
+	}
+	if err := write(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := write(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package deckgen
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const fluxcsv = `#datatype,string,long,dateTime:RFC3339,double,string,string
+#group,false,false,false,false,true,true
+#default,_result,,,,,
+,result,table,_time,_value,_field,_measurement
+,,0,2024-01-01T00:00:00Z,1.5,usage,cpu
+,,0,2024-01-01T00:01:00Z,2.5,usage,cpu
+
+#datatype,string,long,string,dateTime:RFC3339,double
+#group,false,false,true,false,false
+#default,_result,,,,
+,result,table,_measurement,_time,_value
+,,1,mem,2024-01-01T00:00:00Z,40
+`
+
+func TestParseFluxCSV(t *testing.T) {
+	series, err := parsefluxcsv(strings.NewReader(fluxcsv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series) != 2 {
+		t.Fatalf("parsefluxcsv: %d series, want 2", len(series))
+	}
+	if s := series[0]; s.Name != "cpu.usage" || len(s.Values) != 2 || s.Values[1] != 2.5 {
+		t.Errorf("series 0 = %+v", s)
+	}
+	if s := series[1]; s.Name != "mem" || len(s.Values) != 1 || s.Values[0] != 40 ||
+		!s.Times[0].Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("series 1 = %+v", s)
+	}
+}
+
+func TestCheckSeries(t *testing.T) {
+	good := []Series{{Name: "a", Times: []time.Time{{}}, Values: []float64{1}}}
+	if err := checkseries(good); err != nil {
+		t.Errorf("checkseries(good) = %v", err)
+	}
+	bad := []Series{{Name: "a", Values: []float64{1, 2}}}
+	if err := checkseries(bad); err == nil {
+		t.Errorf("checkseries(bad): no error")
+	}
+}
+
+func TestDashboardRunInterval(t *testing.T) {
+	var d Dashboard
+	if err := d.Run(context.Background(), t.TempDir()+"/d.xml", 0); err == nil {
+		t.Errorf("Run with zero interval: no error")
+	}
+}
+
+func TestDashboardNoSource(t *testing.T) {
+	d := Dashboard{Panels: []Panel{{Title: "cpu", Query: "q"}}}
+	var buf bytes.Buffer
+	if err := d.Generate(context.Background(), &buf); err == nil {
+		t.Errorf("Generate without source: no error")
+	}
+	path := t.TempDir() + "/d.xml"
+	if err := d.Run(context.Background(), path, time.Second); err == nil {
+		t.Errorf("Run without source: no error")
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Errorf("Run without source wrote %s", path)
+	}
+}
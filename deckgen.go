@@ -2,64 +2,77 @@
 package deckgen
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
 )
 
 const (
-	circlefmt   = `<ellipse xp="%.2f" yp="%.2f" wp="%.2f" hr="%.2f" opacity="%.2f" color="%s"/>`
-	squarefmt   = `<rect xp="%.2f" yp="%.2f" wp="%.2f" hr="%.2f" opacity="%.2f" color="%s"/>`
-	ellipsefmt  = `<ellipse xp="%.2f" yp="%.2f" wp="%.2f" hp="%.2f" opacity="%.2f" color="%s"/>`
-	rectfmt     = `<rect xp="%.2f" yp="%.2f" wp="%.2f" hp="%.2f" opacity="%.2f" color="%s"/>`
+	circlefmt   = `<ellipse xp="%.2f" yp="%.2f" wp="%.2f" hr="%.2f" opacity="%.2f" color="%s"%s/>`
+	squarefmt   = `<rect xp="%.2f" yp="%.2f" wp="%.2f" hr="%.2f" opacity="%.2f" color="%s"%s/>`
+	ellipsefmt  = `<ellipse xp="%.2f" yp="%.2f" wp="%.2f" hp="%.2f" opacity="%.2f" color="%s"%s/>`
+	rectfmt     = `<rect xp="%.2f" yp="%.2f" wp="%.2f" hp="%.2f" opacity="%.2f" color="%s"%s/>`
+	rectgradfmt = `<rect xp="%.2f" yp="%.2f" wp="%.2f" hp="%.2f" opacity="%.2f" gradcolor1="%s" gradcolor2="%s" gp="%.2f"%s/>`
+	ellgradfmt  = `<ellipse xp="%.2f" yp="%.2f" wp="%.2f" hp="%.2f" opacity="%.2f" gradcolor1="%s" gradcolor2="%s" gp="%.2f"%s/>`
 	arcfmt      = `<arc xp="%.2f" yp="%.2f" wp="%.2f" hp="%.2f" sp="%.2f" a1="%.2f" a2="%.2f" opacity="%.2f" color="%s"/>`
 	linefmt     = `<line xp1="%.2f" yp1="%.2f" xp2="%.2f" yp2="%.2f" sp="%.2f" opacity="%.2f" color="%s"/>`
 	curvefmt    = `<curve xp1="%.2f" yp1="%.2f" xp2="%.2f" yp2="%.2f" xp3="%.2f" yp3="%.2f" sp="%.2f" opacity="%.2f" color="%s"/>`
 	polygonfmt  = `<polygon xc="%s" yc="%s" opacity="%.2f" color="%s"/>`
 	polylinefmt = `<polyline xc="%s" yc="%s" sp="%.2f" opacity="%.2f" color="%s"/>`
-	textfmt     = `<text xp="%.2f" yp="%.2f" sp="%.2f" align="%s" wp="%.2f" font="%s" opacity="%.2f" color="%s" type="%s">%s</text>`
-	textlinkfmt = `<text xp="%.2f" yp="%.2f" sp="%.2f" align="%s" wp="%.2f" font="%s" opacity="%.2f" color="%s" type="%s" link="%s">%s</text>`
-	textrotfmt  = `<text xp="%.2f" yp="%.2f" sp="%.2f" align="%s" wp="%.2f" font="%s" opacity="%.2f" color="%s" type="%s" link="%s" rotation="%.2f">%s</text>`
-	imagefmt    = `<image xp="%.2f" yp="%.2f" width="%d" height="%d" name="%s" link="%s"/>`
-	listfmt     = `<list type="%s" xp="%.2f" yp="%.2f" sp="%.2f" lp="%.2f" wp="%.2f" font="%s" color="%s">`
+	textfmt     = `<text xp="%.2f" yp="%.2f" sp="%.2f" align="%s" wp="%.2f" font="%s" opacity="%.2f" color="%s" type="%s"%s>%s</text>`
+	textlinkfmt = `<text xp="%.2f" yp="%.2f" sp="%.2f" align="%s" wp="%.2f" font="%s" opacity="%.2f" color="%s" type="%s" link="%s"%s>%s</text>`
+	textrotfmt  = `<text xp="%.2f" yp="%.2f" sp="%.2f" align="%s" wp="%.2f" font="%s" opacity="%.2f" color="%s" type="%s" link="%s" rotation="%.2f"%s>%s</text>`
+	textfilefmt = `<text xp="%.2f" yp="%.2f" sp="%.2f" align="%s" wp="%.2f" font="%s" opacity="%.2f" color="%s" type="%s" file="%s"%s>%s</text>`
+	imagefmt    = `<image xp="%.2f" yp="%.2f" width="%d" height="%d" name="%s" link="%s"%s/>`
+	listfmt     = `<list type="%s" xp="%.2f" yp="%.2f" sp="%.2f" lp="%.2f" wp="%.2f" font="%s" color="%s"%s>`
 	lifmt       = `<li>%s</li>`
 	closelist   = `</list>`
 	slidefmt    = `<slide>`
 	slidebg     = `<slide bg="%s">`
 	slidebgfg   = `<slide bg="%s" fg="%s">`
 	closeslide  = `</slide>`
+	notefmt     = `<note>%s</note>`
 	deckfmt     = `<deck><canvas width="%d" height="%d"/>`
+	canvasfmt   = `<canvas width="%d" height="%d"/>`
 	closedeck   = `</deck>`
 )
 
 // deckmarkup defines the structure of a presentation deck
 // The size of the canvas, and series of slides
 type Deck struct {
-	Title       string  `xml:"title"`
-	Creator     string  `xml:"creator"`
-	Subject     string  `xml:"subject"`
-	Publisher   string  `xml:"publisher"`
-	Description string  `xml:"description"`
-	Date        string  `xml:"date"`
-	Canvas      canvas  `xml:"canvas"`
-	Slide       []Slide `xml:"slide"`
+	XMLName     xml.Name `xml:"deck"`
+	Title       string   `xml:"title,omitempty"`
+	Creator     string   `xml:"creator,omitempty"`
+	Subject     string   `xml:"subject,omitempty"`
+	Publisher   string   `xml:"publisher,omitempty"`
+	Description string   `xml:"description,omitempty"`
+	Date        string   `xml:"date,omitempty"`
+	Canvas      canvas   `xml:"canvas"`
+	Slide       []Slide  `xml:"slide"`
 }
 
 type canvas struct {
-	Width  int `xml:"width,attr"`
-	Height int `xml:"height,attr"`
+	Width  int `xml:"width,attr,omitempty"`
+	Height int `xml:"height,attr,omitempty"`
 }
 
 // Slide is the structure of an individual slide within a deck
 // <slide bg="black" fg="rgb(255,255,255)" duration="2s" note="hello, world">
 // <slide gradcolor1="black" gradcolor2="white" gp="20" duration="2s" note="wassup">
 type Slide struct {
-	Bg          string     `xml:"bg,attr"`
-	Fg          string     `xml:"fg,attr"`
-	Gradcolor1  string     `xml:"gradcolor1,attr"`
-	Gradcolor2  string     `xml:"gradcolor2,attr"`
-	GradPercent float64    `xml:"gp,attr"`
-	Duration    string     `xml:"duration,attr"`
-	Note        string     `xml:"note"`
+	Bg          string     `xml:"bg,attr,omitempty"`
+	Fg          string     `xml:"fg,attr,omitempty"`
+	Gradcolor1  string     `xml:"gradcolor1,attr,omitempty"`
+	Gradcolor2  string     `xml:"gradcolor2,attr,omitempty"`
+	GradPercent float64    `xml:"gp,attr,omitempty"`
+	Duration    string     `xml:"duration,attr,omitempty"`
+	Note        string     `xml:"note,omitempty"`
 	List        []List     `xml:"list"`
 	Text        []Text     `xml:"text"`
 	Image       []Image    `xml:"image"`
@@ -74,29 +87,29 @@ type Slide struct {
 
 // CommonAttr are the common attributes for text and list
 type CommonAttr struct {
-	Xp          float64 `xml:"xp,attr"`         // X coordinate
-	Yp          float64 `xml:"yp,attr"`         // Y coordinate
-	Sp          float64 `xml:"sp,attr"`         // size
-	Lp          float64 `xml:"lp,attr"`         // linespacing (leading) percentage
-	Rotation    float64 `xml:"rotation,attr"`   // Rotation (0-360 degrees)
-	Type        string  `xml:"type,attr"`       // type: block, plain, code, number, bullet
-	Align       string  `xml:"align,attr"`      // alignment: center, end, begin
-	Color       string  `xml:"color,attr"`      // item color
-	Gradcolor1  string  `xml:"gradcolor1,attr"` // gradient color 1
-	Gradcolor2  string  `xml:"gradcolor2,attr"` // gradient color 2
-	GradPercent float64 `xml:"gp,attr"`         // gradient percentage
-	Opacity     float64 `xml:"opacity,attr"`    // opacity percentage
-	Font        string  `xml:"font,attr"`       // font type: i.e. sans, serif, mono
-	Link        string  `xml:"link,attr"`       // reference to other content (i.e. http:// or mailto:)
+	Xp          float64 `xml:"xp,attr,omitempty"`         // X coordinate
+	Yp          float64 `xml:"yp,attr,omitempty"`         // Y coordinate
+	Sp          float64 `xml:"sp,attr,omitempty"`         // size
+	Lp          float64 `xml:"lp,attr,omitempty"`         // linespacing (leading) percentage
+	Rotation    float64 `xml:"rotation,attr,omitempty"`   // Rotation (0-360 degrees)
+	Type        string  `xml:"type,attr,omitempty"`       // type: block, plain, code, number, bullet
+	Align       string  `xml:"align,attr,omitempty"`      // alignment: center, end, begin
+	Color       string  `xml:"color,attr,omitempty"`      // item color
+	Gradcolor1  string  `xml:"gradcolor1,attr,omitempty"` // gradient color 1
+	Gradcolor2  string  `xml:"gradcolor2,attr,omitempty"` // gradient color 2
+	GradPercent float64 `xml:"gp,attr,omitempty"`         // gradient percentage
+	Opacity     float64 `xml:"opacity,attr,omitempty"`    // opacity percentage
+	Font        string  `xml:"font,attr,omitempty"`       // font type: i.e. sans, serif, mono
+	Link        string  `xml:"link,attr,omitempty"`       // reference to other content (i.e. http:// or mailto:)
 }
 
 // Dimension describes a graphics object with width and height
 type Dimension struct {
 	CommonAttr
-	Wp float64 `xml:"wp,attr"` // width percentage
-	Hp float64 `xml:"hp,attr"` // height percentage
-	Hr float64 `xml:"hr,attr"` // height relative percentage
-	Hw float64 `xml:"hw,attr"` // height by width
+	Wp float64 `xml:"wp,attr,omitempty"` // width percentage
+	Hp float64 `xml:"hp,attr,omitempty"` // height percentage
+	Hr float64 `xml:"hr,attr,omitempty"` // height relative percentage
+	Hw float64 `xml:"hw,attr,omitempty"` // height by width
 }
 
 // ListItem describes a list item
@@ -107,24 +120,25 @@ type Dimension struct {
 //
 // </list>
 type ListItem struct {
-	Color    string  `xml:"color,attr"`
-	Opacity  float64 `xml:"opacity,attr"`
-	Font     string  `xml:"font,attr"`
+	Color    string  `xml:"color,attr,omitempty"`
+	Opacity  float64 `xml:"opacity,attr,omitempty"`
+	Font     string  `xml:"font,attr,omitempty"`
+	Link     string  `xml:"link,attr,omitempty"`
 	ListText string  `xml:",chardata"`
 }
 
 // List describes the list element
 type List struct {
 	CommonAttr
-	Wp float64    `xml:"wp,attr"`
+	Wp float64    `xml:"wp,attr,omitempty"`
 	Li []ListItem `xml:"li"`
 }
 
 // Text describes the text element
 type Text struct {
 	CommonAttr
-	Wp    float64 `xml:"wp,attr"`
-	File  string  `xml:"file,attr"`
+	Wp    float64 `xml:"wp,attr,omitempty"`
+	File  string  `xml:"file,attr,omitempty"`
 	Tdata string  `xml:",chardata"`
 }
 
@@ -132,12 +146,12 @@ type Text struct {
 // <image xp="20" yp="30" width="256" height="256" scale="50" name="picture.png" caption="Pretty picture"/>
 type Image struct {
 	CommonAttr
-	Width     int     `xml:"width,attr"`     // image width
-	Height    int     `xml:"height,attr"`    // image height
-	Scale     float64 `xml:"scale,attr"`     // image scale percentage
-	Autoscale string  `xml:"autoscale,attr"` // scale the image to the canvas
-	Name      string  `xml:"name,attr"`      // image file name
-	Caption   string  `xml:"caption,attr"`   // image caption
+	Width     int     `xml:"width,attr,omitempty"`     // image width
+	Height    int     `xml:"height,attr,omitempty"`    // image height
+	Scale     float64 `xml:"scale,attr,omitempty"`     // image scale percentage
+	Autoscale string  `xml:"autoscale,attr,omitempty"` // scale the image to the canvas
+	Name      string  `xml:"name,attr,omitempty"`      // image file name
+	Caption   string  `xml:"caption,attr,omitempty"`   // image caption
 }
 
 // Ellipse describes a rectangle with x,y,w,h
@@ -155,28 +169,28 @@ type Rect struct {
 // Line defines a straight line
 // <line xp1="20" yp1="10" xp2="30" yp2="10"/>
 type Line struct {
-	Xp1     float64 `xml:"xp1,attr"`     // begin x coordinate
-	Yp1     float64 `xml:"yp1,attr"`     // begin y coordinate
-	Xp2     float64 `xml:"xp2,attr"`     // end x coordinate
-	Yp2     float64 `xml:"yp2,attr"`     // end y coordinate
-	Sp      float64 `xml:"sp,attr"`      // line thickness
-	Color   string  `xml:"color,attr"`   // line color
-	Opacity float64 `xml:"opacity,attr"` // line opacity (1-100)
+	Xp1     float64 `xml:"xp1,attr,omitempty"`     // begin x coordinate
+	Yp1     float64 `xml:"yp1,attr,omitempty"`     // begin y coordinate
+	Xp2     float64 `xml:"xp2,attr,omitempty"`     // end x coordinate
+	Yp2     float64 `xml:"yp2,attr,omitempty"`     // end y coordinate
+	Sp      float64 `xml:"sp,attr,omitempty"`      // line thickness
+	Color   string  `xml:"color,attr,omitempty"`   // line color
+	Opacity float64 `xml:"opacity,attr,omitempty"` // line opacity (1-100)
 }
 
 // Curve defines a quadratic Bezier curve
 // The begining, ending, and control points are required:
 // <curve xp1="60" yp1="10" xp2="75" yp2="20" xp3="70" yp3="10" />
 type Curve struct {
-	Xp1     float64 `xml:"xp1,attr"`
-	Yp1     float64 `xml:"yp1,attr"`
-	Xp2     float64 `xml:"xp2,attr"`
-	Yp2     float64 `xml:"yp2,attr"`
-	Xp3     float64 `xml:"xp3,attr"`
-	Yp3     float64 `xml:"yp3,attr"`
-	Sp      float64 `xml:"sp,attr"`
-	Color   string  `xml:"color,attr"`
-	Opacity float64 `xml:"opacity,attr"`
+	Xp1     float64 `xml:"xp1,attr,omitempty"`
+	Yp1     float64 `xml:"yp1,attr,omitempty"`
+	Xp2     float64 `xml:"xp2,attr,omitempty"`
+	Yp2     float64 `xml:"yp2,attr,omitempty"`
+	Xp3     float64 `xml:"xp3,attr,omitempty"`
+	Yp3     float64 `xml:"yp3,attr,omitempty"`
+	Sp      float64 `xml:"sp,attr,omitempty"`
+	Color   string  `xml:"color,attr,omitempty"`
+	Opacity float64 `xml:"opacity,attr,omitempty"`
 }
 
 // Arc defines an elliptical arc
@@ -184,42 +198,193 @@ type Curve struct {
 // <arc xp="55"  yp="10" wp="4" hr="75" a1="0" a2="180"/>
 type Arc struct {
 	Dimension
-	A1      float64 `xml:"a1,attr"`
-	A2      float64 `xml:"a2,attr"`
-	Sp      float64 `xml:"sp,attr"`
-	Opacity float64 `xml:"opacity,attr"`
+	A1      float64 `xml:"a1,attr,omitempty"`
+	A2      float64 `xml:"a2,attr,omitempty"`
+	Sp      float64 `xml:"sp,attr,omitempty"`
+	Opacity float64 `xml:"opacity,attr,omitempty"`
 }
 
 // Polygon defines a polygon, x and y coordinates are specified by
 // strings of space-separated percentages:
 // <polygon xc="10 20 30" yc="30 40 50"/>
 type Polygon struct {
-	XC      string  `xml:"xc,attr"`
-	YC      string  `xml:"yc,attr"`
-	Color   string  `xml:"color,attr"`
-	Opacity float64 `xml:"opacity,attr"`
+	XC      string  `xml:"xc,attr,omitempty"`
+	YC      string  `xml:"yc,attr,omitempty"`
+	Color   string  `xml:"color,attr,omitempty"`
+	Opacity float64 `xml:"opacity,attr,omitempty"`
 }
 
 // Polyline defines a polyline, x and y coordinates are specified by
 // strings of space-separated percentages:
 // <polyline xc="10 20 30" yc="30 40 50"/>
 type Polyline struct {
-	XC      string  `xml:"xc,attr"`
-	YC      string  `xml:"yc,attr"`
-	Sp      float64 `xml:"sp,attr"` // line thickness
-	Color   string  `xml:"color,attr"`
-	Opacity float64 `xml:"opacity,attr"`
+	XC      string  `xml:"xc,attr,omitempty"`
+	YC      string  `xml:"yc,attr,omitempty"`
+	Sp      float64 `xml:"sp,attr,omitempty"` // line thickness
+	Color   string  `xml:"color,attr,omitempty"`
+	Opacity float64 `xml:"opacity,attr,omitempty"`
 }
 
 // DeckGen is the generated deck structure.
 type DeckGen struct {
 	width, height int
 	dest          io.Writer
+	w             *errwriter
+	buf           *bufio.Writer
+	snap          float64
+	rawtext       bool
+	slidecolors   []string
+	closer        io.Closer
+	ended         bool
+	ctx           SlideContext
+	inslide       bool
+	layer         int
+	layers        map[int]*bytes.Buffer
+	xforms        []xform
+	assetdir      string
+	strict        StrictMode
+	fonts         map[string]string
+	deffont       string
+	rtl           bool
+	emoji         bool
+	nslides       int
+	sections      []Section
+	header        *marginline
+	footer        *marginline
+	pagenums      *pagenumbers
+	progress      *progress
+	watermark     *watermark
+	deferred      *bytes.Buffer
+	deferdest     io.Writer
+	slideat       map[int]int
+	toc           *tocslide
+	masters       map[string]string
+	theme         Theme
+	defcolor      string
+	defopacity    float64
+	deflinewidth  float64
+	paginate      float64
+	strictErr     error
 }
 
 // NewSlides initializes he generated deck structure.
 func NewSlides(where io.Writer, w, h int) *DeckGen {
-	return &DeckGen{dest: where, width: w, height: h}
+	ew := &errwriter{w: where}
+	buf := bufio.NewWriter(ew)
+	return &DeckGen{dest: buf, w: ew, buf: buf, width: w, height: h}
+}
+
+// Presets are named canvas sizes, in pixels. A4 and letter are landscape, at 96 pixels per inch.
+var Presets = map[string][2]int{
+	"widescreen": {1920, 1080},
+	"standard":   {1024, 768},
+	"square":     {1080, 1080},
+	"a4":         {1123, 794},
+	"letter":     {1056, 816},
+}
+
+// NewSlidesPreset initializes the generated deck structure with a named canvas size,
+// such as "widescreen" or "A4"; see Presets. Names are not case sensitive.
+func NewSlidesPreset(where io.Writer, preset string) (*DeckGen, error) {
+	size, ok := Presets[strings.ToLower(preset)]
+	if !ok {
+		return nil, fmt.Errorf("unknown canvas preset %q", preset)
+	}
+	return NewSlides(where, size[0], size[1]), nil
+}
+
+// errwriter records the first error writing to w; once it fails, later writes are discarded.
+type errwriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errwriter) Write(b []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(b)
+	if err == nil && n < len(b) {
+		err = io.ErrShortWrite
+	}
+	e.err = err
+	return n, err
+}
+
+// Err returns the first error writing the deck, or nil.
+// Writing stops at the first error, so check Err after EndDeck or Flush to detect a truncated deck.
+// In StrictError mode, Err also reports the first out of range element; see SetStrict.
+func (p *DeckGen) Err() error {
+	if p.w != nil && p.w.err != nil {
+		return p.w.err
+	}
+	return p.strictErr
+}
+
+// xmlescaper escapes markup characters in text and attribute values.
+var xmlescaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+
+// esc escapes s for use in markup, unless raw text is enabled.
+func (p *DeckGen) esc(s string) string {
+	if p.rawtext {
+		return s
+	}
+	return xmlescaper.Replace(s)
+}
+
+// SetRawText turns escaping of text and attribute values off (raw is true) or on.
+// With escaping off, strings are emitted as given, for callers that pass markup or entities.
+func (p *DeckGen) SetRawText(raw bool) {
+	p.rawtext = raw
+}
+
+// Flush writes any buffered output, and returns the first write error, if any.
+// Output is buffered, and is flushed by EndDeck, Flush and Close.
+func (p *DeckGen) Flush() error {
+	if p.buf == nil {
+		return p.Err()
+	}
+	if err := p.buf.Flush(); err != nil && p.Err() == nil {
+		return err
+	}
+	return p.Err()
+}
+
+// SetSnap sets the grid increment (in canvas percentages) to which all emitted
+// coordinates and dimensions are rounded; dimensions that are not zero are at least
+// one increment. A step of zero disables snapping.
+func (p *DeckGen) SetSnap(step float64) {
+	p.snap = step
+}
+
+// snapto rounds v to the nearest multiple of the snap increment, if set.
+func (p *DeckGen) snapto(v float64) float64 {
+	if p.snap <= 0 {
+		return v
+	}
+	return math.Round(v/p.snap) * p.snap
+}
+
+// snapsize rounds the dimension v like snapto, keeping a dimension that is not zero
+// at least one increment.
+func (p *DeckGen) snapsize(v float64) float64 {
+	s := p.snapto(v)
+	if s == 0 && v != 0 {
+		return math.Copysign(p.snap, v)
+	}
+	return s
+}
+
+// snapcoords rounds a slice of coordinates to the snap increment.
+func (p *DeckGen) snapcoords(v []float64) []float64 {
+	if p.snap <= 0 {
+		return v
+	}
+	s := make([]float64, len(v))
+	for i, c := range v {
+		s[i] = p.snapto(c)
+	}
+	return s
 }
 
 // StartDeck begins a slide
@@ -227,100 +392,343 @@ func (p *DeckGen) StartDeck() {
 	fmt.Fprintf(p.dest, deckfmt, p.width, p.height)
 }
 
-// EndDeck ends a slide.
+// DeckMeta is the descriptive metadata of a deck.
+type DeckMeta struct {
+	Title       string
+	Creator     string
+	Subject     string
+	Publisher   string
+	Description string
+	Date        string
+}
+
+// StartDeckMeta begins a deck, including its metadata. Empty fields are omitted.
+func (p *DeckGen) StartDeckMeta(meta DeckMeta) {
+	io.WriteString(p.dest, "<deck>")
+	for _, m := range []struct{ name, value string }{
+		{"title", meta.Title},
+		{"creator", meta.Creator},
+		{"subject", meta.Subject},
+		{"publisher", meta.Publisher},
+		{"description", meta.Description},
+		{"date", meta.Date},
+	} {
+		if m.value != "" {
+			fmt.Fprintf(p.dest, "<%s>%s</%s>", m.name, p.esc(m.value), m.name)
+		}
+	}
+	fmt.Fprintf(p.dest, canvasfmt, p.width, p.height)
+}
+
+// EndDeck ends a deck, completes any slides held for page numbering or progress, and flushes the output.
 func (p *DeckGen) EndDeck() {
+	p.assemble()
 	fmt.Fprintln(p.dest, closedeck)
+	p.ended = true
+	p.Flush()
 }
 
 // StartSlide begins a slide.
 func (p *DeckGen) StartSlide(colors ...string) {
+	p.slidecolors = colors
+	p.inslide = true
+	p.slidebegun()
 	switch len(colors) {
 	case 1:
-		fmt.Fprintf(p.dest, slidebg, colors[0])
+		fmt.Fprintf(p.dest, slidebg, p.esc(colors[0]))
 	case 2:
-		fmt.Fprintf(p.dest, slidebgfg, colors[0], colors[1])
+		fmt.Fprintf(p.dest, slidebgfg, p.esc(colors[0]), p.esc(colors[1]))
 	default:
 		fmt.Fprintln(p.dest, slidefmt)
 	}
 }
 
+// StartSlideDuration begins a slide, with optional background and foreground colors,
+// that advances automatically after d.
+func (p *DeckGen) StartSlideDuration(d time.Duration, colors ...string) {
+	p.slidecolors = colors
+	p.inslide = true
+	p.slidebegun()
+	io.WriteString(p.dest, "<slide")
+	if len(colors) > 0 {
+		fmt.Fprintf(p.dest, ` bg="%s"`, p.esc(colors[0]))
+	}
+	if len(colors) > 1 {
+		fmt.Fprintf(p.dest, ` fg="%s"`, p.esc(colors[1]))
+	}
+	fmt.Fprintf(p.dest, ` duration="%s">`, d)
+}
+
+// StartSlideNote begins a slide with background and foreground colors, and speaker notes.
+func (p *DeckGen) StartSlideNote(bg, fg, note string) {
+	p.StartSlide(bg, fg)
+	p.Note(note)
+}
+
+// Note adds speaker notes to the current slide.
+func (p *DeckGen) Note(s string) {
+	fmt.Fprintf(p.out(), notefmt, p.esc(s))
+}
+
 // EndSlide ends a slide.
 func (p *DeckGen) EndSlide() {
+	p.decorate()
+	p.flushlayers()
 	fmt.Fprintln(p.dest, closeslide)
+	p.ctx = SlideContext{}
+}
+
+// shapeattrs makes the optional attributes of shape markup.
+func (p *DeckGen) shapeattrs(d Dimension) string {
+	var attrs string
+	if d.Rotation != 0 {
+		attrs += fmt.Sprintf(` rotation="%.2f"`, d.Rotation)
+	}
+	return attrs
 }
 
 // square makes square markup from the rect structure.
 func (p *DeckGen) square(r Rect) {
-	fmt.Fprintf(p.dest, squarefmt, r.Xp, r.Yp, r.Wp, r.Hr, r.Opacity, r.Color)
+	r.Dimension = p.xfdim(r.Dimension)
+	p.check(r, r.Opacity, r.Xp, r.Yp)
+	fmt.Fprintf(p.out(), squarefmt, p.snapto(r.Xp), p.snapto(r.Yp), p.snapsize(r.Wp), r.Hr, r.Opacity, p.esc(p.colorof(r.Color)), p.shapeattrs(r.Dimension))
 }
 
 // circle makes square markup from the ellipse structure.
 func (p *DeckGen) circle(e Ellipse) {
-	fmt.Fprintf(p.dest, circlefmt, e.Xp, e.Yp, e.Wp, e.Hr, e.Opacity, e.Color)
+	e.Dimension = p.xfdim(e.Dimension)
+	p.check(e, e.Opacity, e.Xp, e.Yp)
+	fmt.Fprintf(p.out(), circlefmt, p.snapto(e.Xp), p.snapto(e.Yp), p.snapsize(e.Wp), e.Hr, e.Opacity, p.esc(p.colorof(e.Color)), p.shapeattrs(e.Dimension))
 }
 
 // ellipse makes ellipse markup from the ellipse structure.
 func (p *DeckGen) ellipse(e Ellipse) {
-	fmt.Fprintf(p.dest, ellipsefmt, e.Xp, e.Yp, e.Wp, e.Hp, e.Opacity, e.Color)
+	e.Dimension = p.xfdim(e.Dimension)
+	p.check(e, e.Opacity, e.Xp, e.Yp)
+	fmt.Fprintf(p.out(), ellipsefmt, p.snapto(e.Xp), p.snapto(e.Yp), p.snapsize(e.Wp), p.snapsize(e.Hp), e.Opacity, p.esc(p.colorof(e.Color)), p.shapeattrs(e.Dimension))
 }
 
 // rect makes rect markup rom the rect structure.
 func (p *DeckGen) rect(r Rect) {
-	fmt.Fprintf(p.dest, rectfmt, r.Xp, r.Yp, r.Wp, r.Hp, r.Opacity, r.Color)
+	r.Dimension = p.xfdim(r.Dimension)
+	p.check(r, r.Opacity, r.Xp, r.Yp)
+	fmt.Fprintf(p.out(), rectfmt, p.snapto(r.Xp), p.snapto(r.Yp), p.snapsize(r.Wp), p.snapsize(r.Hp), r.Opacity, p.esc(p.colorof(r.Color)), p.shapeattrs(r.Dimension))
+}
+
+// rectgradient makes gradient filled rect markup from the rect structure.
+func (p *DeckGen) rectgradient(r Rect) {
+	r.Dimension = p.xfdim(r.Dimension)
+	p.check(r, r.Opacity, r.Xp, r.Yp)
+	fmt.Fprintf(p.out(), rectgradfmt, p.snapto(r.Xp), p.snapto(r.Yp), p.snapsize(r.Wp), p.snapsize(r.Hp), r.Opacity, p.esc(r.Gradcolor1), p.esc(r.Gradcolor2), r.GradPercent, p.shapeattrs(r.Dimension))
+}
+
+// ellipsegradient makes gradient filled ellipse markup from the ellipse structure.
+func (p *DeckGen) ellipsegradient(e Ellipse) {
+	e.Dimension = p.xfdim(e.Dimension)
+	p.check(e, e.Opacity, e.Xp, e.Yp)
+	fmt.Fprintf(p.out(), ellgradfmt, p.snapto(e.Xp), p.snapto(e.Yp), p.snapsize(e.Wp), p.snapsize(e.Hp), e.Opacity, p.esc(e.Gradcolor1), p.esc(e.Gradcolor2), e.GradPercent, p.shapeattrs(e.Dimension))
 }
 
 // line makes line markup from the deck line structure.
 func (p *DeckGen) line(l Line) {
-	fmt.Fprintf(p.dest, linefmt, l.Xp1, l.Yp1, l.Xp2, l.Yp2, l.Sp, l.Opacity, l.Color)
+	l.Xp1, l.Yp1 = p.xfpoint(l.Xp1, l.Yp1)
+	l.Xp2, l.Yp2 = p.xfpoint(l.Xp2, l.Yp2)
+	l.Sp = p.widthof(l.Sp) * p.xfscale()
+	p.check(l, l.Opacity, l.Xp1, l.Yp1, l.Xp2, l.Yp2)
+	fmt.Fprintf(p.out(), linefmt, p.snapto(l.Xp1), p.snapto(l.Yp1), p.snapto(l.Xp2), p.snapto(l.Yp2), l.Sp, l.Opacity, p.esc(p.colorof(l.Color)))
 }
 
 // curve makes curve markup from the curve structure.
 func (p *DeckGen) curve(c Curve) {
-	fmt.Fprintf(p.dest, curvefmt, c.Xp1, c.Yp1, c.Xp2, c.Yp2, c.Xp3, c.Yp3, c.Sp, c.Opacity, c.Color)
+	c.Xp1, c.Yp1 = p.xfpoint(c.Xp1, c.Yp1)
+	c.Xp2, c.Yp2 = p.xfpoint(c.Xp2, c.Yp2)
+	c.Xp3, c.Yp3 = p.xfpoint(c.Xp3, c.Yp3)
+	c.Sp = p.widthof(c.Sp) * p.xfscale()
+	p.check(c, c.Opacity, c.Xp1, c.Yp1, c.Xp2, c.Yp2, c.Xp3, c.Yp3)
+	fmt.Fprintf(p.out(), curvefmt, p.snapto(c.Xp1), p.snapto(c.Yp1), p.snapto(c.Xp2), p.snapto(c.Yp2), p.snapto(c.Xp3), p.snapto(c.Yp3), c.Sp, c.Opacity, p.esc(p.colorof(c.Color)))
 }
 
 // arc makes arc markup from the arc structure.
 func (p *DeckGen) arc(a Arc) {
-	fmt.Fprintf(p.dest, arcfmt, a.Xp, a.Yp, a.Wp, a.Hp, a.Sp, a.A1, a.A2, a.Opacity, a.Color)
+	a.Sp = p.widthof(a.Sp)
+	if len(p.xforms) > 0 {
+		a.Dimension = p.xfdim(a.Dimension)
+		a.Dimension.Rotation = 0
+		a.A1 += p.xfangle()
+		a.A2 += p.xfangle()
+		a.Sp *= p.xfscale()
+	}
+	p.check(a, a.Opacity, a.Xp, a.Yp)
+	fmt.Fprintf(p.out(), arcfmt, p.snapto(a.Xp), p.snapto(a.Yp), p.snapsize(a.Wp), p.snapsize(a.Hp), a.Sp, a.A1, a.A2, a.Opacity, p.esc(p.colorof(a.Color)))
 }
 
 // polygon makes polygon markup from the polygon structure.
 func (p *DeckGen) polygon(poly Polygon) {
-	fmt.Fprintf(p.dest, polygonfmt, poly.XC, poly.YC, poly.Opacity, poly.Color)
+	fmt.Fprintf(p.out(), polygonfmt, poly.XC, poly.YC, poly.Opacity, p.esc(p.colorof(poly.Color)))
 }
 
 // polyline makes polyline markup from the polyline structure.
 func (p *DeckGen) polyline(poly Polyline) {
-	fmt.Fprintf(p.dest, polylinefmt, poly.XC, poly.YC, poly.Sp, poly.Opacity, poly.Color)
+	fmt.Fprintf(p.out(), polylinefmt, poly.XC, poly.YC, p.widthof(poly.Sp), poly.Opacity, p.esc(p.colorof(poly.Color)))
+}
+
+// textattrs makes the optional attributes of text markup.
+func (p *DeckGen) textattrs(t Text) string {
+	var attrs string
+	if t.Lp != 0 {
+		attrs += fmt.Sprintf(` lp="%.2f"`, t.Lp)
+	}
+	if t.Rotation != 0 {
+		attrs += fmt.Sprintf(` rotation="%.2f"`, t.Rotation)
+	}
+	return attrs
 }
 
 // text makes text markup from the deck text structure.
 func (p *DeckGen) text(t Text) {
-	fmt.Fprintf(p.dest, textfmt, t.Xp, t.Yp, t.Sp, t.Align, t.Wp, t.Font, t.Opacity, t.Color, t.Type, t.Tdata)
+	if head, tail, split := p.blocksplit(t); split {
+		rest := t
+		t.Tdata, rest.Tdata = head, tail
+		p.text(t)
+		p.continueslide()
+		rest.Yp = continuedy
+		p.text(rest)
+		return
+	}
+	t = p.xftext(t)
+	t.Tdata = p.shortcodes(t.Tdata)
+	t = p.direct(t)
+	p.check(t, t.Opacity, t.Xp, t.Yp)
+	fmt.Fprintf(p.out(), textfmt, p.snapto(t.Xp), p.snapto(t.Yp), p.sizeof(t.Sp), p.esc(t.Align), p.snapsize(t.Wp), p.esc(p.fontof(t.Font)), t.Opacity, p.esc(p.colorof(t.Color)), p.esc(t.Type), p.textattrs(t), p.esc(t.Tdata))
 }
 
 // textlink makes text markup from the deck text structure, including a link
 func (p *DeckGen) textlink(t Text) {
-	fmt.Fprintf(p.dest, textlinkfmt, t.Xp, t.Yp, t.Sp, t.Align, t.Wp, t.Font, t.Opacity, t.Color, t.Type, t.Link, t.Tdata)
+	t = p.xftext(t)
+	t.Tdata = p.shortcodes(t.Tdata)
+	t = p.direct(t)
+	p.check(t, t.Opacity, t.Xp, t.Yp)
+	fmt.Fprintf(p.out(), textlinkfmt, p.snapto(t.Xp), p.snapto(t.Yp), p.sizeof(t.Sp), p.esc(t.Align), p.snapsize(t.Wp), p.esc(p.fontof(t.Font)), t.Opacity, p.esc(p.colorof(t.Color)), p.esc(t.Type), p.esc(t.Link), p.textattrs(t), p.esc(t.Tdata))
 }
 
 // textrotate makes text markup from the deck text structure, including a link
 func (p *DeckGen) textrotate(t Text) {
-	fmt.Fprintf(p.dest, textrotfmt, t.Xp, t.Yp, t.Sp, t.Align, t.Wp, t.Font, t.Opacity, t.Color, t.Type, t.Link, t.Rotation, t.Tdata)
+	t = p.xftext(t)
+	t.Tdata = p.shortcodes(t.Tdata)
+	t = p.direct(t)
+	p.check(t, t.Opacity, t.Xp, t.Yp)
+	opt := t
+	opt.Rotation = 0 // included in textrotfmt
+	fmt.Fprintf(p.out(), textrotfmt, p.snapto(t.Xp), p.snapto(t.Yp), p.sizeof(t.Sp), p.esc(t.Align), p.snapsize(t.Wp), p.esc(p.fontof(t.Font)), t.Opacity, p.esc(p.colorof(t.Color)), p.esc(t.Type), p.esc(t.Link), t.Rotation, p.textattrs(opt), p.esc(t.Tdata))
+}
+
+// textfile makes text markup from the deck text structure, including a file reference.
+func (p *DeckGen) textfile(t Text) {
+	t = p.xftext(t)
+	t.Tdata = p.shortcodes(t.Tdata)
+	t = p.direct(t)
+	p.check(t, t.Opacity, t.Xp, t.Yp)
+	fmt.Fprintf(p.out(), textfilefmt, p.snapto(t.Xp), p.snapto(t.Yp), p.sizeof(t.Sp), p.esc(t.Align), p.snapsize(t.Wp), p.esc(p.fontof(t.Font)), t.Opacity, p.esc(p.colorof(t.Color)), p.esc(t.Type), p.esc(t.File), p.textattrs(t), p.esc(t.Tdata))
 }
 
 // image makes image markup from the deck image structure.
 func (p *DeckGen) image(pic Image) {
-	fmt.Fprintf(p.dest, imagefmt, pic.Xp, pic.Yp, pic.Width, pic.Height, pic.Name, pic.Link)
+	if len(p.xforms) > 0 {
+		pic.Xp, pic.Yp = p.xfpoint(pic.Xp, pic.Yp)
+		if s := p.xfscale(); s != 1 {
+			if pic.Scale == 0 {
+				pic.Scale = 100
+			}
+			pic.Scale *= s
+		}
+	}
+	p.check(pic, pic.Opacity, pic.Xp, pic.Yp)
+	var opt string
+	if pic.Caption != "" {
+		opt += fmt.Sprintf(` caption="%s"`, p.esc(pic.Caption))
+	}
+	if pic.Scale != 0 {
+		opt += fmt.Sprintf(` scale="%.2f"`, pic.Scale)
+	}
+	if pic.Autoscale != "" {
+		opt += fmt.Sprintf(` autoscale="%s"`, p.esc(pic.Autoscale))
+	}
+	fmt.Fprintf(p.out(), imagefmt, p.snapto(pic.Xp), p.snapto(pic.Yp), pic.Width, pic.Height, p.esc(pic.Name), p.esc(pic.Link), opt)
 }
 
 // list makes markup from the list deck structure.
 func (p *DeckGen) list(l List, items []string, ltype, font, color string) {
-	fmt.Fprintf(p.dest, listfmt, ltype, l.Xp, l.Yp, l.Sp, l.Lp, l.Wp, l.Font, l.Color)
+	if n := p.listfit(l, items); n < len(items) {
+		p.list(l, items[:n], ltype, font, color)
+		p.continueslide()
+		l.Yp = continuedy
+		p.list(l, items[n:], ltype, font, color)
+		return
+	}
+	l = p.xflist(l)
+	p.check(l, l.Opacity, l.Xp, l.Yp)
+	fmt.Fprintf(p.out(), listfmt, p.esc(ltype), p.snapto(l.Xp), p.snapto(l.Yp), p.sizeof(l.Sp), l.Lp, p.snapsize(l.Wp), p.esc(p.fontof(l.Font)), p.esc(p.colorof(l.Color)), p.listattrs(l))
 	for _, s := range items {
-		fmt.Fprintf(p.dest, lifmt, s)
+		s = p.shortcodes(s)
+		if p.rtl {
+			s = bidivisual(s)
+		}
+		fmt.Fprintf(p.out(), lifmt, p.esc(s))
 	}
-	fmt.Fprintln(p.dest, closelist)
+	fmt.Fprintln(p.out(), closelist)
+}
+
+// listattrs makes the optional attributes of list markup.
+func (p *DeckGen) listattrs(l List) string {
+	var attrs string
+	if l.Link != "" {
+		attrs += fmt.Sprintf(` link="%s"`, p.esc(l.Link))
+	}
+	if l.Rotation != 0 {
+		attrs += fmt.Sprintf(` rotation="%.2f"`, l.Rotation)
+	}
+	return attrs
+}
+
+// listitems makes markup from the list deck structure, with individually styled items.
+func (p *DeckGen) listitems(l List, ltype string) {
+	texts := make([]string, len(l.Li))
+	for i, li := range l.Li {
+		texts[i] = li.ListText
+	}
+	if n := p.listfit(l, texts); n < len(l.Li) {
+		rest := l
+		l.Li, rest.Li = l.Li[:n], l.Li[n:]
+		p.listitems(l, ltype)
+		p.continueslide()
+		rest.Yp = continuedy
+		p.listitems(rest, ltype)
+		return
+	}
+	l = p.xflist(l)
+	p.check(l, l.Opacity, l.Xp, l.Yp)
+	fmt.Fprintf(p.out(), listfmt, p.esc(ltype), p.snapto(l.Xp), p.snapto(l.Yp), p.sizeof(l.Sp), l.Lp, p.snapsize(l.Wp), p.esc(p.fontof(l.Font)), p.esc(p.colorof(l.Color)), p.listattrs(l))
+	for _, li := range l.Li {
+		io.WriteString(p.out(), "<li")
+		if li.Color != "" {
+			fmt.Fprintf(p.out(), ` color="%s"`, p.esc(li.Color))
+		}
+		if li.Opacity != 0 {
+			fmt.Fprintf(p.out(), ` opacity="%.2f"`, li.Opacity)
+		}
+		if li.Font != "" {
+			fmt.Fprintf(p.out(), ` font="%s"`, p.esc(li.Font))
+		}
+		if li.Link != "" {
+			fmt.Fprintf(p.out(), ` link="%s"`, p.esc(li.Link))
+		}
+		text := p.shortcodes(li.ListText)
+		if p.rtl {
+			text = bidivisual(text)
+		}
+		fmt.Fprintf(p.out(), ">%s</li>", p.esc(text))
+	}
+	fmt.Fprintln(p.out(), closelist)
 }
 
 // Text places plain text aligned at (x,y), with specified font, size and color. Opacity is optional
@@ -335,7 +743,7 @@ func (p *DeckGen) Text(x, y float64, s, font string, size float64, color string,
 	if len(opacity) > 0 {
 		t.Opacity = opacity[0]
 	} else {
-		t.Opacity = 100
+		t.Opacity = p.defaultopacity()
 	}
 	p.text(t)
 }
@@ -353,7 +761,7 @@ func (p *DeckGen) TextMid(x, y float64, s, font string, size float64, color stri
 	if len(opacity) > 0 {
 		t.Opacity = opacity[0]
 	} else {
-		t.Opacity = 100
+		t.Opacity = p.defaultopacity()
 	}
 	p.text(t)
 }
@@ -371,7 +779,7 @@ func (p *DeckGen) TextEnd(x, y float64, s, font string, size float64, color stri
 	if len(opacity) > 0 {
 		t.Opacity = opacity[0]
 	} else {
-		t.Opacity = 100
+		t.Opacity = p.defaultopacity()
 	}
 	p.text(t)
 }
@@ -390,7 +798,48 @@ func (p *DeckGen) TextBlock(x, y float64, s, font string, size, margin float64,
 	if len(opacity) > 0 {
 		t.Opacity = opacity[0]
 	} else {
-		t.Opacity = 100
+		t.Opacity = p.defaultopacity()
+	}
+	p.text(t)
+}
+
+// TextBlockRotate makes a block of text aligned at (x,y), wrapped at margin, rotated by rotation degrees;
+// with specified font, size and color. Opacity is optional.
+func (p *DeckGen) TextBlockRotate(x, y float64, s, font string, rotation, size, margin float64, color string, opacity ...float64) {
+	t := Text{}
+	t.Xp = x
+	t.Yp = y
+	t.Sp = size
+	t.Font = font
+	t.Wp = margin
+	t.Tdata = s
+	t.Color = color
+	t.Rotation = rotation
+	t.Type = "block"
+	if len(opacity) > 0 {
+		t.Opacity = opacity[0]
+	} else {
+		t.Opacity = p.defaultopacity()
+	}
+	p.textrotate(t)
+}
+
+// TextBlockSpacing makes a block of text like TextBlock, with the specified line spacing.
+func (p *DeckGen) TextBlockSpacing(x, y float64, s, font string, size, margin, spacing float64, color string, opacity ...float64) {
+	t := Text{}
+	t.Xp = x
+	t.Yp = y
+	t.Sp = size
+	t.Lp = spacing
+	t.Font = font
+	t.Wp = margin
+	t.Tdata = s
+	t.Color = color
+	t.Type = "block"
+	if len(opacity) > 0 {
+		t.Opacity = opacity[0]
+	} else {
+		t.Opacity = p.defaultopacity()
 	}
 	p.text(t)
 }
@@ -409,7 +858,7 @@ func (p *DeckGen) TextLink(x, y float64, s, link, font string, size float64, col
 	if len(opacity) > 0 {
 		t.Opacity = opacity[0]
 	} else {
-		t.Opacity = 100
+		t.Opacity = p.defaultopacity()
 	}
 	p.textlink(t)
 }
@@ -429,12 +878,44 @@ func (p *DeckGen) TextRotate(x, y float64, s, link, font string, rotation, size
 	if len(opacity) > 0 {
 		t.Opacity = opacity[0]
 	} else {
-		t.Opacity = 100
+		t.Opacity = p.defaultopacity()
 	}
 	p.textrotate(t)
 }
 
-// Code makes a code block at (x,y), with specified size and color (opacity is optional),
+// TextFile places text read by the renderer from filename, aligned at (x,y), with specified font, size and color.
+func (p *DeckGen) TextFile(x, y float64, filename, font string, size float64, color string) {
+	t := Text{}
+	t.Xp = x
+	t.Yp = y
+	t.Sp = size
+	t.Font = font
+	t.Color = color
+	t.File = filename
+	t.Opacity = p.defaultopacity()
+	p.textfile(t)
+}
+
+// TextFileInline places the contents of filename, aligned at (x,y), with specified font, size and color.
+// Unlike TextFile, the deck does not depend on the file.
+func (p *DeckGen) TextFileInline(x, y float64, filename, font string, size float64, color string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	t := Text{}
+	t.Xp = x
+	t.Yp = y
+	t.Sp = size
+	t.Font = font
+	t.Color = color
+	t.Tdata = string(data)
+	t.Opacity = p.defaultopacity()
+	p.text(t)
+	return nil
+}
+
+// Code makes a code block at (x,y) in the theme's mono font, with specified size and color (opacity is optional),
 // on a light gray background with the specified margin width.
 func (p *DeckGen) Code(x, y float64, s string, size, margin float64, color string, opacity ...float64) {
 	t := Text{}
@@ -445,10 +926,31 @@ func (p *DeckGen) Code(x, y float64, s string, size, margin float64, color strin
 	t.Tdata = s
 	t.Color = color
 	t.Type = "code"
+	t.Font = p.themeof(nil).Mono
 	if len(opacity) > 0 {
 		t.Opacity = opacity[0]
 	} else {
-		t.Opacity = 100
+		t.Opacity = p.defaultopacity()
+	}
+	p.text(t)
+}
+
+// CodeSpacing makes a code block like Code, with the specified line spacing.
+func (p *DeckGen) CodeSpacing(x, y float64, s string, size, margin, spacing float64, color string, opacity ...float64) {
+	t := Text{}
+	t.Xp = x
+	t.Yp = y
+	t.Sp = size
+	t.Lp = spacing
+	t.Wp = margin
+	t.Tdata = s
+	t.Color = color
+	t.Type = "code"
+	t.Font = p.themeof(nil).Mono
+	if len(opacity) > 0 {
+		t.Opacity = opacity[0]
+	} else {
+		t.Opacity = p.defaultopacity()
 	}
 	p.text(t)
 }
@@ -466,6 +968,39 @@ func (p *DeckGen) List(x, y, size, spacing, wrap float64, items []string, ltype,
 	p.list(l, items, ltype, font, color)
 }
 
+// ListRotate makes a list like List, rotated by rotation degrees.
+func (p *DeckGen) ListRotate(x, y, size, spacing, wrap float64, items []string, ltype, font, color string, rotation float64) {
+	l := List{}
+	l.Xp = x
+	l.Yp = y
+	l.Sp = size
+	l.Lp = spacing
+	l.Wp = wrap
+	l.Font = font
+	l.Color = color
+	l.Rotation = rotation
+	p.list(l, items, ltype, font, color)
+}
+
+// ListItems makes a plain, bullet, or number list of individually styled items, with optional spacing.
+// Items without a color, opacity, or font use those of the list; items with a link may be followed.
+func (p *DeckGen) ListItems(x, y, size, spacing, wrap float64, items []ListItem, ltype string) {
+	p.ListItemsLink(x, y, size, spacing, wrap, items, ltype, "")
+}
+
+// ListItemsLink makes a list of individually styled items, like ListItems, with a link for the list as a whole.
+func (p *DeckGen) ListItemsLink(x, y, size, spacing, wrap float64, items []ListItem, ltype, link string) {
+	l := List{}
+	l.Xp = x
+	l.Yp = y
+	l.Sp = size
+	l.Lp = spacing
+	l.Wp = wrap
+	l.Link = link
+	l.Li = items
+	p.listitems(l, ltype)
+}
+
 // Square makes a square, centered at (x,y), with width w, at the specified color and optional opacity.
 func (p *DeckGen) Square(x, y, w float64, color string, opacity ...float64) {
 	r := Rect{}
@@ -477,7 +1012,7 @@ func (p *DeckGen) Square(x, y, w float64, color string, opacity ...float64) {
 	if len(opacity) > 0 {
 		r.Opacity = opacity[0]
 	} else {
-		r.Opacity = 100
+		r.Opacity = p.defaultopacity()
 	}
 	p.square(r)
 }
@@ -493,7 +1028,7 @@ func (p *DeckGen) Circle(x, y, w float64, color string, opacity ...float64) {
 	if len(opacity) > 0 {
 		e.Opacity = opacity[0]
 	} else {
-		e.Opacity = 100
+		e.Opacity = p.defaultopacity()
 	}
 	p.circle(e)
 }
@@ -509,7 +1044,7 @@ func (p *DeckGen) Rect(x, y, w, h float64, color string, opacity ...float64) {
 	if len(opacity) > 0 {
 		r.Opacity = opacity[0]
 	} else {
-		r.Opacity = 100
+		r.Opacity = p.defaultopacity()
 	}
 	p.rect(r)
 }
@@ -525,7 +1060,114 @@ func (p *DeckGen) Ellipse(x, y, w, h float64, color string, opacity ...float64)
 	if len(opacity) > 0 {
 		e.Opacity = opacity[0]
 	} else {
-		e.Opacity = 100
+		e.Opacity = p.defaultopacity()
+	}
+	p.ellipse(e)
+}
+
+// RectGradient makes a rectangle, centered at (x,y), with (w,h) dimensions, filled with a gradient
+// from color1 to color2, with the gradient percentage gp and optional opacity.
+func (p *DeckGen) RectGradient(x, y, w, h float64, color1, color2 string, gp float64, opacity ...float64) {
+	r := Rect{}
+	r.Xp = x
+	r.Yp = y
+	r.Wp = w
+	r.Hp = h
+	r.Gradcolor1 = color1
+	r.Gradcolor2 = color2
+	r.GradPercent = gp
+	if len(opacity) > 0 {
+		r.Opacity = opacity[0]
+	} else {
+		r.Opacity = p.defaultopacity()
+	}
+	p.rectgradient(r)
+}
+
+// EllipseGradient makes an ellipse, centered at (x,y), with (w,h) dimensions, filled with a gradient
+// from color1 to color2, with the gradient percentage gp and optional opacity.
+func (p *DeckGen) EllipseGradient(x, y, w, h float64, color1, color2 string, gp float64, opacity ...float64) {
+	e := Ellipse{}
+	e.Xp = x
+	e.Yp = y
+	e.Wp = w
+	e.Hp = h
+	e.Gradcolor1 = color1
+	e.Gradcolor2 = color2
+	e.GradPercent = gp
+	if len(opacity) > 0 {
+		e.Opacity = opacity[0]
+	} else {
+		e.Opacity = p.defaultopacity()
+	}
+	p.ellipsegradient(e)
+}
+
+// SquareRotate makes a square like Square, rotated by rotation degrees about its center.
+func (p *DeckGen) SquareRotate(x, y, w, rotation float64, color string, opacity ...float64) {
+	r := Rect{}
+	r.Xp = x
+	r.Yp = y
+	r.Wp = w
+	r.Hr = 100
+	r.Rotation = rotation
+	r.Color = color
+	if len(opacity) > 0 {
+		r.Opacity = opacity[0]
+	} else {
+		r.Opacity = p.defaultopacity()
+	}
+	p.square(r)
+}
+
+// CircleRotate makes a circle like Circle, rotated by rotation degrees about its center.
+// Rotation is visible with gradient or pattern fills.
+func (p *DeckGen) CircleRotate(x, y, w, rotation float64, color string, opacity ...float64) {
+	e := Ellipse{}
+	e.Xp = x
+	e.Yp = y
+	e.Wp = w
+	e.Hr = 100
+	e.Rotation = rotation
+	e.Color = color
+	if len(opacity) > 0 {
+		e.Opacity = opacity[0]
+	} else {
+		e.Opacity = p.defaultopacity()
+	}
+	p.circle(e)
+}
+
+// RectRotate makes a rectangle like Rect, rotated by rotation degrees about its center.
+func (p *DeckGen) RectRotate(x, y, w, h, rotation float64, color string, opacity ...float64) {
+	r := Rect{}
+	r.Xp = x
+	r.Yp = y
+	r.Wp = w
+	r.Hp = h
+	r.Rotation = rotation
+	r.Color = color
+	if len(opacity) > 0 {
+		r.Opacity = opacity[0]
+	} else {
+		r.Opacity = p.defaultopacity()
+	}
+	p.rect(r)
+}
+
+// EllipseRotate makes an ellipse like Ellipse, rotated by rotation degrees about its center.
+func (p *DeckGen) EllipseRotate(x, y, w, h, rotation float64, color string, opacity ...float64) {
+	e := Ellipse{}
+	e.Xp = x
+	e.Yp = y
+	e.Wp = w
+	e.Hp = h
+	e.Rotation = rotation
+	e.Color = color
+	if len(opacity) > 0 {
+		e.Opacity = opacity[0]
+	} else {
+		e.Opacity = p.defaultopacity()
 	}
 	p.ellipse(e)
 }
@@ -536,7 +1178,7 @@ func (p *DeckGen) Line(x1, y1, x2, y2, size float64, color string, opacity ...fl
 	if len(opacity) > 0 {
 		l.Opacity = opacity[0]
 	} else {
-		l.Opacity = 100
+		l.Opacity = p.defaultopacity()
 	}
 	p.line(l)
 }
@@ -554,7 +1196,7 @@ func (p *DeckGen) Arc(x, y, w, h, size, a1, a2 float64, color string, opacity ..
 	if len(opacity) > 0 {
 		a.Opacity = opacity[0]
 	} else {
-		a.Opacity = 100
+		a.Opacity = p.defaultopacity()
 	}
 	p.arc(a)
 }
@@ -565,28 +1207,36 @@ func (p *DeckGen) Curve(x1, y1, x2, y2, x3, y3, size float64, color string, opac
 	if len(opacity) > 0 {
 		c.Opacity = opacity[0]
 	} else {
-		c.Opacity = 100
+		c.Opacity = p.defaultopacity()
 	}
 	p.curve(c)
 }
 
 // Polygon makes a polygon with the specified color (with optional opacity), with coordinates in x and y slices.
 func (p *DeckGen) Polygon(x, y []float64, color string, opacity ...float64) {
-	xc, yc := Polycoord(x, y)
+	x, y = p.xfpoints(x, y)
+	xc, yc := Polycoord(p.snapcoords(x), p.snapcoords(y))
 	poly := Polygon{XC: xc, YC: yc, Color: color}
 	if len(opacity) > 0 {
 		poly.Opacity = opacity[0]
+	} else {
+		poly.Opacity = p.defaultopacity()
 	}
+	p.check(poly, poly.Opacity, append(x[:len(x):len(x)], y...)...)
 	p.polygon(poly)
 }
 
 // Polyline makes a polyline with the specified color and thickness (with optional opacity), with coordinates in x and y slices.
 func (p *DeckGen) Polyline(x, y []float64, size float64, color string, opacity ...float64) {
-	xc, yc := Polycoord(x, y)
-	poly := Polyline{XC: xc, YC: yc, Sp: size, Color: color}
+	x, y = p.xfpoints(x, y)
+	xc, yc := Polycoord(p.snapcoords(x), p.snapcoords(y))
+	poly := Polyline{XC: xc, YC: yc, Sp: size * p.xfscale(), Color: color}
 	if len(opacity) > 0 {
 		poly.Opacity = opacity[0]
+	} else {
+		poly.Opacity = p.defaultopacity()
 	}
+	p.check(poly, poly.Opacity, append(x[:len(x):len(x)], y...)...)
 	p.polyline(poly)
 }
 
@@ -614,3 +1264,31 @@ func (p *DeckGen) Image(x, y float64, w, h int, name, link string) {
 	i.CommonAttr.Link = link
 	p.image(i)
 }
+
+// ImageScaled places an image, centered at (x,y), scaled to scale percent of its natural dimensions.
+func (p *DeckGen) ImageScaled(x, y float64, name string, scale float64, link string) {
+	i := Image{Name: name, Scale: scale}
+	i.Xp = x
+	i.Yp = y
+	i.CommonAttr.Link = link
+	p.image(i)
+}
+
+// ImageAutoscale places an image, centered at (x,y), that renderers scale to fit the canvas.
+// The dimensions (w, h) give the image's aspect ratio.
+func (p *DeckGen) ImageAutoscale(x, y float64, w, h int, name, link string) {
+	i := Image{Width: w, Height: h, Name: name, Autoscale: "on"}
+	i.Xp = x
+	i.Yp = y
+	i.CommonAttr.Link = link
+	p.image(i)
+}
+
+// ImageCaption places an image, centered at (x,y), with a caption, which renderers draw below the image.
+func (p *DeckGen) ImageCaption(x, y float64, w, h int, name, caption, link string) {
+	i := Image{Width: w, Height: h, Name: name, Caption: caption}
+	i.Xp = x
+	i.Yp = y
+	i.CommonAttr.Link = link
+	p.image(i)
+}
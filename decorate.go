@@ -0,0 +1,123 @@
+package deckgen
+
+// FooterOptions are optional settings of the text set by SetFooter and SetHeader.
+type FooterOptions struct {
+	Align string  // begin, center or end (default: center)
+	Font  string  // text font (default: the slide font)
+	Size  float64 // text size (default: 1.2)
+	Color string  // text color (default: the theme's muted color)
+}
+
+// marginline is a line of text repeated in the top or bottom margin of each slide.
+type marginline struct {
+	text string
+	FooterOptions
+}
+
+// newmarginline returns a margin line with the defaults filled in, or nil if text is empty.
+func (p *DeckGen) newmarginline(text string, opts []FooterOptions) *marginline {
+	if text == "" {
+		return nil
+	}
+	m := &marginline{text: text}
+	if len(opts) > 0 {
+		m.FooterOptions = opts[0]
+	}
+	if m.Size == 0 {
+		m.Size = 1.2
+	}
+	if m.Color == "" {
+		m.Color = p.themeof(nil).Muted
+	}
+	return m
+}
+
+// draw places the margin line with its baseline at y.
+func (m *marginline) draw(p *DeckGen, y float64) {
+	switch m.Align {
+	case "begin", "start", "left":
+		p.textaligned(5, y, m.text, m.Font, m.Size, m.Color, "", 100)
+	case "end", "right":
+		p.textaligned(95, y, m.text, m.Font, m.Size, m.Color, "end", 100)
+	default:
+		p.textaligned(50, y, m.text, m.Font, m.Size, m.Color, "center", 100)
+	}
+}
+
+// SetFooter sets text, such as an event name, copyright notice or date, to be placed at the
+// bottom of every following slide. An empty text removes the footer.
+func (p *DeckGen) SetFooter(text string, opts ...FooterOptions) {
+	p.footer = p.newmarginline(text, opts)
+}
+
+// SetHeader sets text to be placed at the top of every following slide, like SetFooter.
+func (p *DeckGen) SetHeader(text string, opts ...FooterOptions) {
+	p.header = p.newmarginline(text, opts)
+}
+
+// watermark is text or an image placed behind the content of each slide.
+type watermark struct {
+	text, image       string
+	width, height     int
+	opacity, rotation float64
+}
+
+// SetWatermark sets text, such as "DRAFT" or "CONFIDENTIAL", to be placed large and faint
+// across every following slide, behind its content, at the given opacity and rotation.
+// An empty text removes the watermark.
+func (p *DeckGen) SetWatermark(text string, opacity, rotation float64) {
+	p.watermark = nil
+	if text != "" {
+		p.watermark = &watermark{text: text, opacity: opacity, rotation: rotation}
+	}
+}
+
+// SetWatermarkImage sets an image to be placed at the center of every following slide,
+// behind its content, faded to the given opacity by a wash of the slide background.
+// Image names are as for ImageFile.
+func (p *DeckGen) SetWatermarkImage(name string, opacity float64) error {
+	name, w, h, err := p.imagesource(name, false)
+	if err != nil {
+		return err
+	}
+	p.watermark = &watermark{image: name, width: w, height: h, opacity: opacity}
+	return nil
+}
+
+// draw places the watermark.
+func (m *watermark) draw(p *DeckGen) {
+	if m.image != "" {
+		p.Image(50, 50, m.width, m.height, m.image, "")
+		bg := p.themeof(nil).Bg
+		if len(p.slidecolors) > 0 {
+			bg = p.slidecolors[0]
+		}
+		p.Rect(50, 50, 100, 100, bg, 100-m.opacity)
+		return
+	}
+	t := Text{Tdata: m.text}
+	t.Sp = fitsize(m.text, p.fontof(""), 12, 80)
+	t.Xp, t.Yp = 50, 50-p.hpct(t.Sp*0.35)
+	t.Align = "center"
+	t.Rotation = m.rotation
+	t.Color = p.themeof(nil).Muted
+	t.Opacity = m.opacity
+	t.Type = "plain"
+	p.textrotate(t)
+}
+
+// decorate adds the deck-wide elements, such as the header and footer, to the current slide.
+func (p *DeckGen) decorate() {
+	if p.watermark != nil {
+		p.inlayer(BackgroundLayer-1, p.watermark.draw)
+	}
+	p.inlayer(ForegroundLayer, func(p *DeckGen) {
+		if p.header != nil {
+			p.header.draw(p, 96)
+		}
+		if p.footer != nil {
+			p.footer.draw(p, 2.5)
+		}
+		p.slidemark()
+	})
+}
@@ -0,0 +1,56 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWatermark(t *testing.T) {
+	tests := []struct {
+		name    string
+		numbers bool
+		want    []string // page numbers
+	}{
+		{name: "plain"},
+		{name: "page numbers", numbers: true, want: []string{"1 / 3", "2 / 3", "3 / 3"}},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.SetWatermark("DRAFT", 20, 30)
+		if test.numbers {
+			p.EnablePageNumbers("", "")
+		}
+		p.StartDeck()
+		for i := 0; i < 3; i++ {
+			if i == 2 {
+				p.SetWatermark("", 0, 0)
+			}
+			p.StartSlide()
+			p.Text(10, 50, "content", "sans", 3, "")
+			p.EndSlide()
+		}
+		p.EndDeck()
+		out := buf.String()
+		slides := strings.Split(out, "<slide")[1:]
+		for i, s := range slides {
+			n := strings.Count(s, ">DRAFT<")
+			if want := map[bool]int{true: 1, false: 0}[i < 2]; n != want {
+				t.Errorf("%s: slide %d has %d watermarks, want %d", test.name, i+1, n, want)
+			}
+			// the watermark is behind the content
+			if n > 0 && strings.Index(s, ">DRAFT<") > strings.Index(s, ">content<") {
+				t.Errorf("%s: slide %d: watermark in front of the content", test.name, i+1)
+			}
+			if n > 0 && !strings.Contains(s, `opacity="20.00"`) || n > 0 && !strings.Contains(s, `rotation="30.00"`) {
+				t.Errorf("%s: slide %d: watermark lacks its opacity or rotation", test.name, i+1)
+			}
+		}
+		if test.numbers {
+			if got := slidenumbers(out); strings.Join(got, "|") != strings.Join(test.want, "|") {
+				t.Errorf("%s: page numbers %q, want %q", test.name, got, test.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package deckgen
+
+// textaligned places text with the given alignment: "center", "end", or the default, left.
+func (p *DeckGen) textaligned(x, y float64, s, font string, size float64, color, align string, opacity float64) {
+	switch align {
+	case "center":
+		p.TextMid(x, y, s, font, size, color, opacity)
+	case "end":
+		p.TextEnd(x, y, s, font, size, color, opacity)
+	default:
+		p.Text(x, y, s, font, size, color, opacity)
+	}
+}
+
+// shadowoffset is the offset of text shadows, as a fraction of the text size.
+const shadowoffset = 0.06
+
+// textshadow places text over a copy offset down and right in the shadow color, at half opacity.
+func (p *DeckGen) textshadow(x, y float64, s, font string, size float64, color, shadow, align string) {
+	d := p.sizeof(size) * shadowoffset
+	p.textaligned(x+d, y-p.hpct(d), s, font, size, shadow, align, 50)
+	p.textaligned(x, y, s, font, size, color, align, 100)
+}
+
+// TextShadow places text aligned at (x,y), like Text, with a drop shadow in the shadow color.
+func (p *DeckGen) TextShadow(x, y float64, s, font string, size float64, color, shadow string) {
+	p.textshadow(x, y, s, font, size, color, shadow, "")
+}
+
+// TextMidShadow places centered text, like TextMid, with a drop shadow in the shadow color.
+func (p *DeckGen) TextMidShadow(x, y float64, s, font string, size float64, color, shadow string) {
+	p.textshadow(x, y, s, font, size, color, shadow, "center")
+}
+
+// TextEndShadow places right-justified text, like TextEnd, with a drop shadow in the shadow color.
+func (p *DeckGen) TextEndShadow(x, y float64, s, font string, size float64, color, shadow string) {
+	p.textshadow(x, y, s, font, size, color, shadow, "end")
+}
+
+// textoutline places text over copies offset in eight directions in the outline color.
+func (p *DeckGen) textoutline(x, y float64, s, font string, size float64, color, outline string, width float64, align string) {
+	for a := 0.0; a < 360; a += 45 {
+		ox, oy := p.polar(x, y, width, a)
+		p.textaligned(ox, oy, s, font, size, outline, align, 100)
+	}
+	p.textaligned(x, y, s, font, size, color, align, 100)
+}
+
+// TextOutline places text aligned at (x,y), like Text, outlined in the outline color,
+// with the outline width in canvas width percentages. The outline is simulated with offset copies.
+func (p *DeckGen) TextOutline(x, y float64, s, font string, size float64, color, outline string, width float64) {
+	p.textoutline(x, y, s, font, size, color, outline, width, "")
+}
+
+// TextMidOutline places centered outlined text, like TextOutline.
+func (p *DeckGen) TextMidOutline(x, y float64, s, font string, size float64, color, outline string, width float64) {
+	p.textoutline(x, y, s, font, size, color, outline, width, "center")
+}
@@ -0,0 +1,120 @@
+package deckgen
+
+import (
+	"regexp"
+	"strings"
+)
+
+// shortcode matches emoji shortcodes such as :rocket:.
+var shortcode = regexp.MustCompile(`:[a-z0-9_+-]+:`)
+
+// emojis maps common shortcodes, as used by chat and issue trackers, to emoji.
+var emojis = map[string]string{
+	"+1":                         "👍",
+	"-1":                         "👎",
+	"100":                        "💯",
+	"alarm_clock":                "⏰",
+	"arrow_down":                 "⬇️",
+	"arrow_left":                 "⬅️",
+	"arrow_right":                "➡️",
+	"arrow_up":                   "⬆️",
+	"bangbang":                   "‼️",
+	"beer":                       "🍺",
+	"bell":                       "🔔",
+	"bomb":                       "💣",
+	"book":                       "📖",
+	"boom":                       "💥",
+	"bug":                        "🐛",
+	"bulb":                       "💡",
+	"calendar":                   "📆",
+	"chart_with_downwards_trend": "📉",
+	"chart_with_upwards_trend":   "📈",
+	"check":                      "✔️",
+	"clap":                       "👏",
+	"clipboard":                  "📋",
+	"cloud":                      "☁️",
+	"coffee":                     "☕",
+	"computer":                   "💻",
+	"construction":               "🚧",
+	"cry":                        "😢",
+	"dart":                       "🎯",
+	"email":                      "📧",
+	"eyes":                       "👀",
+	"fire":                       "🔥",
+	"gear":                       "⚙️",
+	"gift":                       "🎁",
+	"globe_with_meridians":       "🌐",
+	"grin":                       "😁",
+	"hammer":                     "🔨",
+	"heart":                      "❤️",
+	"hourglass":                  "⌛",
+	"information_source":         "ℹ️",
+	"joy":                        "😂",
+	"key":                        "🔑",
+	"laughing":                   "😆",
+	"link":                       "🔗",
+	"lock":                       "🔒",
+	"mag":                        "🔍",
+	"memo":                       "📝",
+	"moneybag":                   "💰",
+	"muscle":                     "💪",
+	"no_entry":                   "⛔",
+	"ok_hand":                    "👌",
+	"package":                    "📦",
+	"pencil":                     "📝",
+	"pray":                       "🙏",
+	"pushpin":                    "📌",
+	"question":                   "❓",
+	"rainbow":                    "🌈",
+	"recycle":                    "♻️",
+	"rocket":                     "🚀",
+	"rotating_light":             "🚨",
+	"scream":                     "😱",
+	"seedling":                   "🌱",
+	"shipit":                     "🐿️",
+	"smile":                      "😄",
+	"smiley":                     "😃",
+	"sparkles":                   "✨",
+	"star":                       "⭐",
+	"sunny":                      "☀️",
+	"tada":                       "🎉",
+	"thinking":                   "🤔",
+	"thumbsdown":                 "👎",
+	"thumbsup":                   "👍",
+	"trophy":                     "🏆",
+	"unlock":                     "🔓",
+	"warning":                    "⚠️",
+	"wave":                       "👋",
+	"white_check_mark":           "✅",
+	"wink":                       "😉",
+	"wrench":                     "🔧",
+	"x":                          "❌",
+	"zap":                        "⚡",
+}
+
+// SetEmoji turns on (or off) the expansion of emoji shortcodes, such as :rocket: or :tada:,
+// in text and list items. Unknown shortcodes are left as they are.
+func (p *DeckGen) SetEmoji(on bool) {
+	p.emoji = on
+}
+
+// expandemoji replaces the known shortcodes in s with emoji.
+func expandemoji(s string) string {
+	if !strings.Contains(s, ":") {
+		return s
+	}
+	return shortcode.ReplaceAllStringFunc(s, func(code string) string {
+		if e, ok := emojis[code[1:len(code)-1]]; ok {
+			return e
+		}
+		return code
+	})
+}
+
+// shortcodes expands emoji shortcodes in s, if enabled.
+func (p *DeckGen) shortcodes(s string) string {
+	if !p.emoji {
+		return s
+	}
+	return expandemoji(s)
+}
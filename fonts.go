@@ -0,0 +1,16 @@
+package deckgen
+
+// RegisterFont makes alias a name for the deck font name, so that generators can use
+// semantic names such as "heading" or "body", and retarget a deck by changing the registration.
+// Aliases may be used wherever a font is given, including slide contexts.
+func (p *DeckGen) RegisterFont(alias, name string) {
+	if p.fonts == nil {
+		p.fonts = map[string]string{}
+	}
+	p.fonts[alias] = name
+}
+
+// SetDefaultFont sets the font used by elements and slide contexts without one.
+func (p *DeckGen) SetDefaultFont(font string) {
+	p.deffont = font
+}
@@ -0,0 +1,286 @@
+package deckgen
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// texsymbols maps LaTeX commands to Unicode symbols.
+var texsymbols = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ε", "varepsilon": "ε",
+	"zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ", "iota": "ι", "kappa": "κ",
+	"lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ", "pi": "π", "varpi": "ϖ", "rho": "ρ",
+	"sigma": "σ", "varsigma": "ς", "tau": "τ", "upsilon": "υ", "phi": "φ", "varphi": "ϕ",
+	"chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π",
+	"Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+	"times": "×", "cdot": "·", "pm": "±", "mp": "∓", "div": "÷", "ast": "∗",
+	"leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠", "ne": "≠", "approx": "≈",
+	"equiv": "≡", "sim": "∼", "propto": "∝", "infty": "∞", "partial": "∂", "nabla": "∇",
+	"sum": "∑", "prod": "∏", "int": "∫", "oint": "∮", "in": "∈", "notin": "∉",
+	"subset": "⊂", "subseteq": "⊆", "cup": "∪", "cap": "∩", "forall": "∀", "exists": "∃",
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "Rightarrow": "⇒", "Leftrightarrow": "⇔",
+	"ldots": "…", "cdots": "⋯", "prime": "′", "degree": "°", "emptyset": "∅",
+	"langle": "⟨", "rangle": "⟩", "{": "{", "}": "}", "%": "%", "$": "$", "&": "&", "#": "#",
+	"sin": "sin", "cos": "cos", "tan": "tan", "log": "log", "ln": "ln", "exp": "exp",
+	"lim": "lim", "max": "max", "min": "min", "det": "det",
+}
+
+// texspaces maps LaTeX spacing commands to widths, as a fraction of the size.
+var texspaces = map[string]float64{
+	",": 0.17, ":": 0.22, ";": 0.28, " ": 0.33, "quad": 1, "qquad": 2, "!": -0.17,
+}
+
+// mbox is a laid out piece of a formula; dimensions are in canvas width percentages.
+type mbox struct {
+	w, asc, desc float64
+	draw         func(p *DeckGen, x, y float64) // y is the baseline; nil for empty boxes
+}
+
+// render draws the box, if it has anything to draw.
+func (b mbox) render(p *DeckGen, x, y float64) {
+	if b.draw != nil {
+		b.draw(p, x, y)
+	}
+}
+
+// formula holds the parser state.
+type formula struct {
+	src   []rune
+	pos   int
+	color string
+}
+
+// token returns the next token: a command (with its backslash), a brace, or a single character.
+func (f *formula) token() string {
+	for f.pos < len(f.src) && unicode.IsSpace(f.src[f.pos]) {
+		f.pos++
+	}
+	if f.pos >= len(f.src) {
+		return ""
+	}
+	c := f.src[f.pos]
+	f.pos++
+	if c != '\\' || f.pos >= len(f.src) {
+		return string(c)
+	}
+	start := f.pos
+	for f.pos < len(f.src) && unicode.IsLetter(f.src[f.pos]) {
+		f.pos++
+	}
+	if f.pos == start {
+		f.pos++
+	}
+	return "\\" + string(f.src[start:f.pos])
+}
+
+// peek returns the next token without consuming it.
+func (f *formula) peek() string {
+	save := f.pos
+	t := f.token()
+	f.pos = save
+	return t
+}
+
+// text makes a box for a run of text.
+func (f *formula) text(s string, size float64) mbox {
+	font := "serif"
+	w := textwidth(s, font, size)
+	color := f.color
+	return mbox{w: w, asc: 0.72 * size, desc: 0.22 * size, draw: func(p *DeckGen, x, y float64) {
+		p.Text(x, y, s, font, size, color)
+	}}
+}
+
+// hlist lays out boxes side by side.
+func hlist(boxes []mbox) mbox {
+	var b mbox
+	for _, c := range boxes {
+		b.w += c.w
+		b.asc = math.Max(b.asc, c.asc)
+		b.desc = math.Max(b.desc, c.desc)
+	}
+	b.draw = func(p *DeckGen, x, y float64) {
+		for _, c := range boxes {
+			c.render(p, x, y)
+			x += c.w
+		}
+	}
+	return b
+}
+
+// expr parses tokens until the closing token (or the end) into a horizontal list.
+func (f *formula) expr(size float64, until string) mbox {
+	var boxes []mbox
+	for {
+		t := f.peek()
+		if t == "" || t == until {
+			if t != "" {
+				f.token()
+			}
+			break
+		}
+		boxes = append(boxes, f.atom(size))
+	}
+	return hlist(boxes)
+}
+
+// arg parses a braced group or a single token.
+func (f *formula) arg(size float64) mbox {
+	if f.peek() == "{" {
+		f.token()
+		return f.expr(size, "}")
+	}
+	return f.base(size)
+}
+
+// atom parses a base followed by any superscript and subscript.
+func (f *formula) atom(size float64) mbox {
+	b := f.base(size)
+	var sup, sub *mbox
+	for {
+		switch f.peek() {
+		case "^":
+			f.token()
+			s := f.arg(size * 0.7)
+			sup = &s
+			continue
+		case "_":
+			f.token()
+			s := f.arg(size * 0.7)
+			sub = &s
+			continue
+		}
+		break
+	}
+	if sup == nil && sub == nil {
+		return b
+	}
+	out := mbox{w: b.w, asc: b.asc, desc: b.desc}
+	rise, drop := size*0.42, size*0.22
+	sw := 0.0
+	if sup != nil {
+		out.asc = math.Max(out.asc, rise+sup.asc)
+		sw = sup.w
+	}
+	if sub != nil {
+		out.desc = math.Max(out.desc, drop+sub.desc)
+		sw = math.Max(sw, sub.w)
+	}
+	out.w += sw + size*0.05
+	out.draw = func(p *DeckGen, x, y float64) {
+		b.render(p, x, y)
+		if sup != nil {
+			sup.render(p, x+b.w, y+p.hpct(rise))
+		}
+		if sub != nil {
+			sub.render(p, x+b.w, y-p.hpct(drop))
+		}
+	}
+	return out
+}
+
+// base parses a single element: a group, fraction, root, symbol or character.
+func (f *formula) base(size float64) mbox {
+	t := f.token()
+	switch {
+	case t == "{":
+		return f.expr(size, "}")
+	case t == `\frac`:
+		return f.frac(f.arg(size*0.85), f.arg(size*0.85), size)
+	case t == `\sqrt`:
+		return f.sqrt(f.arg(size), size)
+	case t == `\left` || t == `\right`:
+		return f.base(size)
+	case t == `\mathrm` || t == `\text` || t == `\mathbf` || t == `\mathit`:
+		return f.arg(size)
+	case strings.HasPrefix(t, `\`):
+		name := t[1:]
+		if w, ok := texspaces[name]; ok {
+			return mbox{w: w * size}
+		}
+		if s, ok := texsymbols[name]; ok {
+			b := f.text(s, size)
+			if len([]rune(s)) > 1 {
+				b.w += size * 0.15 // function names are followed by a thin space
+			}
+			return b
+		}
+		return f.text(name, size)
+	case t == "." || t == "" || t == "}":
+		return mbox{}
+	}
+	r := []rune(t)[0]
+	if strings.ContainsRune("+-=<>", r) {
+		if r == '-' {
+			t = "−"
+		}
+		b := f.text(t, size)
+		pad := size * 0.2
+		inner := b.draw
+		b.w += 2 * pad
+		b.draw = func(p *DeckGen, x, y float64) { inner(p, x+pad, y) }
+		return b
+	}
+	// collect a run of digits so numbers are drawn as one element
+	if unicode.IsDigit(r) {
+		for f.pos < len(f.src) && (unicode.IsDigit(f.src[f.pos]) || f.src[f.pos] == '.') {
+			t += string(f.src[f.pos])
+			f.pos++
+		}
+	}
+	return f.text(t, size)
+}
+
+// frac stacks the numerator over the denominator, separated by a rule.
+func (f *formula) frac(num, den mbox, size float64) mbox {
+	axis, gap := size*0.28, size*0.12
+	w := math.Max(num.w, den.w) + size*0.2
+	color := f.color
+	return mbox{
+		w:    w,
+		asc:  axis + gap + num.desc + num.asc,
+		desc: gap + den.asc + den.desc - axis,
+		draw: func(p *DeckGen, x, y float64) {
+			ay := y + p.hpct(axis)
+			p.Line(x, ay, x+w, ay, size*0.05, color)
+			num.render(p, x+(w-num.w)/2, ay+p.hpct(gap+num.desc))
+			den.render(p, x+(w-den.w)/2, ay-p.hpct(gap+den.asc))
+		},
+	}
+}
+
+// sqrt draws a radical sign with a rule over the radicand.
+func (f *formula) sqrt(r mbox, size float64) mbox {
+	sign := size * 0.6
+	gap := size * 0.1
+	color := f.color
+	return mbox{
+		w:    sign + r.w + size*0.1,
+		asc:  r.asc + 2*gap,
+		desc: r.desc,
+		draw: func(p *DeckGen, x, y float64) {
+			top := y + p.hpct(r.asc+gap)
+			bottom := y - p.hpct(r.desc)
+			lw := size * 0.05
+			p.Line(x, y+p.hpct(size*0.2), x+sign*0.3, y+p.hpct(size*0.1), lw, color)
+			p.Line(x+sign*0.3, y+p.hpct(size*0.1), x+sign*0.55, bottom, lw, color)
+			p.Line(x+sign*0.55, bottom, x+sign*0.9, top, lw, color)
+			p.Line(x+sign*0.9, top, x+sign+r.w+size*0.1, top, lw, color)
+			r.render(p, x+sign, y)
+		},
+	}
+}
+
+// Formula lays out a formula written in a subset of LaTeX math notation, with
+// its baseline starting at (x, y). Supported are superscripts and subscripts,
+// \frac, \sqrt, Greek letters, common operators and relations, and spacing commands.
+// The optional color defaults to the theme's foreground.
+func (p *DeckGen) Formula(x, y, size float64, tex string, color ...string) {
+	f := &formula{src: []rune(tex), color: p.themeof(nil).Fg}
+	if len(color) > 0 {
+		f.color = color[0]
+	}
+	f.expr(size, "").render(p, x, y)
+}
@@ -0,0 +1,39 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormula(t *testing.T) {
+	tests := []struct {
+		tex  string
+		want []string // text expected in the output
+	}{
+		{`x^2`, []string{">x<", ">2<"}},
+		{`\frac{a}{b}`, []string{">a<", ">b<", "<line"}},
+		{`\sqrt{x}`, []string{">x<", "<line"}},
+		{`\alpha + 1`, []string{">α<", ">+<", ">1<"}},
+		// malformed input is laid out as far as it goes
+		{`x^`, []string{">x<"}},
+		{`x_`, []string{">x<"}},
+		{`x^}`, []string{">x<"}},
+		{`\sqrt`, []string{"<line"}},
+		{`\frac{a}`, []string{">a<"}},
+		{`{`, nil},
+		{`}`, nil},
+		{``, nil},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.Formula(10, 50, 3, test.tex)
+		p.Flush()
+		for _, w := range test.want {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("Formula(%q) = %s, want %s", test.tex, buf.String(), w)
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package deckgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// chesspieces maps FEN piece letters to chess glyphs.
+var chesspieces = map[rune]string{
+	'K': "♔", 'Q': "♕", 'R': "♖", 'B': "♗", 'N': "♘", 'P': "♙",
+	'k': "♚", 'q': "♛", 'r': "♜", 'b': "♝", 'n': "♞", 'p': "♟",
+}
+
+// parsefen returns the board described by the placement field of a FEN string,
+// indexed by rank (0 is rank 8) and file (0 is file a).
+func parsefen(fen string) ([8][8]rune, error) {
+	var board [8][8]rune
+	fields := strings.Fields(fen)
+	if len(fields) == 0 {
+		return board, fmt.Errorf("empty FEN")
+	}
+	ranks := strings.Split(fields[0], "/")
+	if len(ranks) != 8 {
+		return board, fmt.Errorf("FEN %q: need 8 ranks, got %d", fields[0], len(ranks))
+	}
+	for r, rank := range ranks {
+		f := 0
+		for _, c := range rank {
+			switch {
+			case c >= '1' && c <= '8':
+				f += int(c - '0')
+			case chesspieces[c] != "":
+				if f < 8 {
+					board[r][f] = c
+				}
+				f++
+			default:
+				return board, fmt.Errorf("FEN %q: bad piece %q", fields[0], c)
+			}
+		}
+		if f != 8 {
+			return board, fmt.Errorf("FEN %q: rank %d has %d files", fields[0], 8-r, f)
+		}
+	}
+	return board, nil
+}
+
+// Chess draws the chess position given in Forsyth-Edwards Notation, on a board
+// centered at (x, y) with width size. Optional colors are the light and dark squares.
+func (p *DeckGen) Chess(x, y, size float64, fen string, colors ...string) error {
+	board, err := parsefen(fen)
+	if err != nil {
+		return err
+	}
+	light, dark := "rgb(240,217,181)", "rgb(181,136,99)"
+	if len(colors) > 0 {
+		light = colors[0]
+	}
+	if len(colors) > 1 {
+		dark = colors[1]
+	}
+	sq := size / 8
+	left := x - size/2
+	top := y + p.hpct(size)/2
+	ts := sq * 0.7
+	ls := sq * 0.2
+	t := p.themeof(nil)
+	for r := 0; r < 8; r++ {
+		cy := top - p.hpct(sq)*(float64(r)+0.5)
+		for f := 0; f < 8; f++ {
+			cx := left + sq*(float64(f)+0.5)
+			color := light
+			if (r+f)%2 == 1 {
+				color = dark
+			}
+			p.Square(cx, cy, sq, color)
+			if piece := board[r][f]; piece != 0 {
+				p.TextMid(cx, cy-p.hpct(ts)*0.35, chesspieces[piece], t.Font, ts, "black")
+			}
+		}
+		p.TextEnd(left-ls, cy-p.hpct(ls)/2, fmt.Sprintf("%d", 8-r), t.Font, ls, t.Muted)
+	}
+	for f := 0; f < 8; f++ {
+		cx := left + sq*(float64(f)+0.5)
+		p.TextMid(cx, top-p.hpct(size)-p.hpct(ls)*1.5, string(rune('a'+f)), t.Font, ls, t.Muted)
+	}
+	return nil
+}
+
+// sgfpoint converts a two letter SGF coordinate ("aa" is the top left) to column and row.
+func sgfpoint(s string, n int) (int, int, error) {
+	if len(s) != 2 {
+		return 0, 0, fmt.Errorf("bad SGF coordinate %q", s)
+	}
+	c, r := int(s[0]-'a'), int(s[1]-'a')
+	if c < 0 || c >= n || r < 0 || r >= n {
+		return 0, 0, fmt.Errorf("SGF coordinate %q is off a %dx%d board", s, n, n)
+	}
+	return c, r, nil
+}
+
+// starpoints returns the handicap point positions for common board sizes.
+func starpoints(n int) []int {
+	switch n {
+	case 19:
+		return []int{3, 9, 15}
+	case 13:
+		return []int{3, 6, 9}
+	case 9:
+		return []int{2, 4, 6}
+	}
+	return nil
+}
+
+// sgfpoints converts a list of SGF coordinates to columns and rows.
+func sgfpoints(coords []string, n int) ([][2]int, error) {
+	pts := make([][2]int, len(coords))
+	for i, s := range coords {
+		c, r, err := sgfpoint(s, n)
+		if err != nil {
+			return nil, err
+		}
+		pts[i] = [2]int{c, r}
+	}
+	return pts, nil
+}
+
+// GoBoard draws a Go position on an n by n board centered at (x, y) with width size.
+// Stones are listed as SGF coordinates, for example "dd" or "pq".
+func (p *DeckGen) GoBoard(x, y, size float64, n int, black, white []string) error {
+	if n < 2 {
+		return fmt.Errorf("bad board size %d", n)
+	}
+	bpts, err := sgfpoints(black, n)
+	if err != nil {
+		return err
+	}
+	wpts, err := sgfpoints(white, n)
+	if err != nil {
+		return err
+	}
+	step := size / float64(n)
+	left := x - size/2 + step/2
+	top := y + p.hpct(size)/2 - p.hpct(step)/2
+	span := step * float64(n-1)
+	p.Square(x, y, size, "rgb(220,179,92)")
+	for i := 0; i < n; i++ {
+		off := step * float64(i)
+		p.Line(left+off, top, left+off, top-p.hpct(span), 0.1, "black")
+		p.Line(left, top-p.hpct(off), left+span, top-p.hpct(off), 0.1, "black")
+	}
+	for _, r := range starpoints(n) {
+		for _, c := range starpoints(n) {
+			p.Circle(left+step*float64(c), top-p.hpct(step*float64(r)), step/5, "black")
+		}
+	}
+	stones := func(pts [][2]int, fill string) {
+		for _, pt := range pts {
+			cx, cy := left+step*float64(pt[0]), top-p.hpct(step*float64(pt[1]))
+			p.Circle(cx, cy, step*0.95, "black")
+			if fill != "black" {
+				p.Circle(cx, cy, step*0.85, fill)
+			}
+		}
+	}
+	stones(bpts, "black")
+	stones(wpts, "white")
+	return nil
+}
@@ -0,0 +1,60 @@
+package deckgen
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseFEN(t *testing.T) {
+	tests := []struct {
+		fen  string
+		ok   bool
+		r, f int
+		want rune
+	}{
+		{"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", true, 4, 4, 'P'},
+		{"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR", true, 0, 4, 'k'},
+		{"8/8/8/8/8/8/8/7K", true, 7, 7, 'K'},
+		{"", false, 0, 0, 0},
+		{"8/8/8/8/8/8/8", false, 0, 0, 0},
+		{"8/8/8/8/8/8/8/7X", false, 0, 0, 0},
+		{"8/8/8/8/8/8/8/7", false, 0, 0, 0},
+		{"8/8/8/8/8/8/8/K8", false, 0, 0, 0},
+	}
+	for _, test := range tests {
+		board, err := parsefen(test.fen)
+		if (err == nil) != test.ok {
+			t.Errorf("parsefen(%q) error %v, want ok %v", test.fen, err, test.ok)
+			continue
+		}
+		if test.ok && board[test.r][test.f] != test.want {
+			t.Errorf("parsefen(%q)[%d][%d] = %q, want %q", test.fen, test.r, test.f, board[test.r][test.f], test.want)
+		}
+	}
+}
+
+func TestGoBoard(t *testing.T) {
+	tests := []struct {
+		n            int
+		black, white []string
+		ok           bool
+	}{
+		{19, []string{"dd", "pq"}, []string{"qd"}, true},
+		{9, nil, nil, true},
+		{1, nil, nil, false},
+		{9, []string{"aa"}, []string{"zz"}, false},
+		{9, []string{"a"}, nil, false},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		err := p.GoBoard(50, 50, 40, test.n, test.black, test.white)
+		p.Flush()
+		if (err == nil) != test.ok {
+			t.Errorf("GoBoard(%d, %v, %v) error %v, want ok %v", test.n, test.black, test.white, err, test.ok)
+		}
+		if err != nil && buf.Len() > 0 {
+			t.Errorf("GoBoard(%d, %v, %v) drew %s before failing", test.n, test.black, test.white, buf.String())
+		}
+	}
+}
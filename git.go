@@ -0,0 +1,228 @@
+package deckgen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Commit is a commit read from a git log.
+type Commit struct {
+	Hash    string
+	Author  string
+	Date    string
+	Subject string
+	Type    string // conventional commit type (feat, fix, ...), or "other"
+	Files   []FileChange
+}
+
+// FileChange is the number of lines added and deleted in a file by a commit.
+type FileChange struct {
+	Name    string
+	Added   int
+	Deleted int
+}
+
+// committypes are the display names of conventional commit types, in slide order.
+var committypes = []struct{ key, name string }{
+	{"feat", "Features"},
+	{"fix", "Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build"},
+	{"ci", "Continuous Integration"},
+	{"chore", "Chores"},
+	{"other", "Other Changes"},
+}
+
+// committype extracts the conventional commit type from a subject, such as "feat" from "feat(api): add".
+func committype(subject string) string {
+	i := strings.IndexAny(subject, ":(!")
+	if i <= 0 {
+		return "other"
+	}
+	t := strings.ToLower(subject[:i])
+	for _, ct := range committypes {
+		if ct.key == t {
+			return t
+		}
+	}
+	return "other"
+}
+
+// GitLog reads the commits of the repository at repo between two refs (from is exclusive).
+// An empty from reads the history up to the to ref. Refs beginning with "-" are rejected,
+// so that they cannot be taken as options.
+func GitLog(repo, from, to string) ([]Commit, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	for _, ref := range []string{from, to} {
+		if strings.HasPrefix(ref, "-") {
+			return nil, fmt.Errorf("git log: invalid ref %q", ref)
+		}
+	}
+	rev := to
+	if from != "" {
+		rev = from + ".." + to
+	}
+	cmd := exec.Command("git", "-C", repo, "log", "--no-merges", "--numstat", "--date=short",
+		"--format=%x1e%H%x1f%an%x1f%ad%x1f%s", rev)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s: %v: %s", rev, err, strings.TrimSpace(stderr.String()))
+	}
+	return parsegitlog(out), nil
+}
+
+// parsegitlog parses the record-separated output of git log with numstat.
+func parsegitlog(out []byte) []Commit {
+	var commits []Commit
+	for _, rec := range bytes.Split(out, []byte{0x1e}) {
+		sc := bufio.NewScanner(bytes.NewReader(rec))
+		if !sc.Scan() {
+			continue
+		}
+		f := strings.Split(sc.Text(), "\x1f")
+		if len(f) < 4 {
+			continue
+		}
+		c := Commit{Hash: f[0], Author: f[1], Date: f[2], Subject: f[3], Type: committype(f[3])}
+		for sc.Scan() {
+			nf := strings.SplitN(sc.Text(), "\t", 3)
+			if len(nf) != 3 {
+				continue
+			}
+			added, _ := strconv.Atoi(nf[0]) // binary files report "-"
+			deleted, _ := strconv.Atoi(nf[1])
+			c.Files = append(c.Files, FileChange{Name: nf[2], Added: added, Deleted: deleted})
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// gitlistmax is the number of commit subjects listed on each slide.
+const gitlistmax = 12
+
+// GitSlides reads the log of the repository at repo between two refs and generates
+// release notes slides: commits grouped by type, a bar chart of commits by author,
+// and a table of the most changed files. An empty to is HEAD, as in GitLog.
+func (p *DeckGen) GitSlides(repo, from, to string) error {
+	if to == "" {
+		to = "HEAD"
+	}
+	commits, err := GitLog(repo, from, to)
+	if err != nil {
+		return err
+	}
+	rng := to
+	if from != "" {
+		rng = from + " → " + to
+	}
+	t := p.themeof(nil)
+	p.reportslide("")
+	p.TextMid(50, 55, "Release Notes", t.TitleFont, 6, t.Fg)
+	p.TextMid(50, 42, fmt.Sprintf("%s  ·  %d commits", rng, len(commits)), t.Font, 2.5, t.Muted)
+	p.EndSlide()
+
+	bytype := map[string][]string{}
+	for _, c := range commits {
+		s := c.Subject
+		if i := strings.Index(s, ":"); i > 0 && c.Type != "other" {
+			s = strings.TrimSpace(s[i+1:])
+		}
+		bytype[c.Type] = append(bytype[c.Type], s+" ("+c.Author+")")
+	}
+	for _, ct := range committypes {
+		items := bytype[ct.key]
+		for start := 0; start < len(items); start += gitlistmax {
+			end := start + gitlistmax
+			if end > len(items) {
+				end = len(items)
+			}
+			title := ct.name
+			if start > 0 {
+				title += " (continued)"
+			}
+			p.reportslide(title)
+			p.List(10, 78, reportsize, 0, 80, items[start:end], "bullet", t.Font, t.Fg)
+			p.EndSlide()
+		}
+	}
+
+	counts := map[string]int{}
+	for _, c := range commits {
+		counts[c.Author]++
+	}
+	authors := make([]string, 0, len(counts))
+	for a := range counts {
+		authors = append(authors, a)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if counts[authors[i]] != counts[authors[j]] {
+			return counts[authors[i]] > counts[authors[j]]
+		}
+		return authors[i] < authors[j]
+	})
+	if len(authors) > 15 {
+		authors = authors[:15]
+	}
+	values := make([]float64, len(authors))
+	for i, a := range authors {
+		values[i] = float64(counts[a])
+	}
+	p.reportslide("Contributions")
+	p.hbars(Box{X: 10, Y: 10, W: 80, H: 70}, authors, values, t.Accent)
+	p.EndSlide()
+
+	type filestat struct {
+		name           string
+		commits        int
+		added, deleted int
+	}
+	files := map[string]*filestat{}
+	for _, c := range commits {
+		for _, f := range c.Files {
+			fs, ok := files[f.Name]
+			if !ok {
+				fs = &filestat{name: f.Name}
+				files[f.Name] = fs
+			}
+			fs.commits++
+			fs.added += f.Added
+			fs.deleted += f.Deleted
+		}
+	}
+	stats := make([]*filestat, 0, len(files))
+	for _, fs := range files {
+		stats = append(stats, fs)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		ci, cj := stats[i].added+stats[i].deleted, stats[j].added+stats[j].deleted
+		if ci != cj {
+			return ci > cj
+		}
+		return stats[i].name < stats[j].name
+	})
+	if len(stats) > 12 {
+		stats = stats[:12]
+	}
+	rows := make([][]string, len(stats))
+	for i, fs := range stats {
+		rows[i] = []string{fs.name, strconv.Itoa(fs.commits), "+" + strconv.Itoa(fs.added), "-" + strconv.Itoa(fs.deleted)}
+	}
+	p.reportslide(fmt.Sprintf("Files Changed (%d)", len(files)))
+	p.Table(10, 80, 80, []string{"File", "Commits", "Added", "Deleted"}, rows,
+		TableStyle{Align: []string{"begin", "end", "end", "end"}})
+	p.EndSlide()
+	return nil
+}
@@ -0,0 +1,82 @@
+package deckgen
+
+import (
+	"bytes"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGitLogRefs(t *testing.T) {
+	for _, refs := range [][2]string{{"--output=/tmp/x", "HEAD"}, {"", "-p"}} {
+		if _, err := GitLog(".", refs[0], refs[1]); err == nil {
+			t.Errorf("GitLog(%q, %q): no error", refs[0], refs[1])
+		}
+	}
+}
+
+func TestGitSlidesRange(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("no git")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Ann", "-c", "user.email=ann@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "feat: one")
+	git("tag", "v1")
+	git("commit", "-q", "--allow-empty", "-m", "fix: two")
+	tests := []struct {
+		from, to string
+		want     string
+	}{
+		{"", "", ">HEAD  ·  2 commits<"},
+		{"v1", "", ">v1 → HEAD  ·  1 commits<"},
+		{"", "v1", ">v1  ·  1 commits<"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.StartDeck()
+		if err := p.GitSlides(dir, test.from, test.to); err != nil {
+			t.Fatalf("GitSlides(%q, %q): %v", test.from, test.to, err)
+		}
+		p.EndDeck()
+		if out := buf.String(); !strings.Contains(out, test.want) {
+			t.Errorf("GitSlides(%q, %q): output lacks %q", test.from, test.to, test.want)
+		}
+	}
+}
+
+func TestParseGitLog(t *testing.T) {
+	out := "\x1eabc\x1fAnn\x1f2024-01-02\x1ffeat(api): add things\n\n3\t1\tapi.go\n-\t-\tlogo.png\n" +
+		"\x1edef\x1fBob\x1f2024-01-03\x1fUpdate readme\n"
+	want := []Commit{
+		{Hash: "abc", Author: "Ann", Date: "2024-01-02", Subject: "feat(api): add things", Type: "feat",
+			Files: []FileChange{{"api.go", 3, 1}, {"logo.png", 0, 0}}},
+		{Hash: "def", Author: "Bob", Date: "2024-01-03", Subject: "Update readme", Type: "other"},
+	}
+	if got := parsegitlog([]byte(out)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parsegitlog = %+v, want %+v", got, want)
+	}
+}
+
+func TestCommitType(t *testing.T) {
+	tests := map[string]string{
+		"fix: crash":         "fix",
+		"Feat!: breaking":    "feat",
+		"docs(readme): typo": "docs",
+		"wip: stuff":         "other",
+		"no colon":           "other",
+	}
+	for subject, want := range tests {
+		if got := committype(subject); got != want {
+			t.Errorf("committype(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
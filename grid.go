@@ -0,0 +1,27 @@
+package deckgen
+
+import "fmt"
+
+// Grid draws gridlines every step percent, labelled with their coordinates, in front of
+// the other elements of the current slide. It is an aid to positioning elements
+// while developing a generator.
+func (p *DeckGen) Grid(step float64, color string, opacity float64) {
+	if step <= 0 {
+		return
+	}
+	p.Foreground(func(p *DeckGen) {
+		for v := 0.0; v <= 100; v += step {
+			thick := 0.05
+			if int(v+0.5)%10 == 0 {
+				thick = 0.1
+			}
+			p.Line(v, 0, v, 100, thick, color, opacity)
+			p.Line(0, v, 100, v, thick, color, opacity)
+			if v < 100 {
+				label := fmt.Sprintf("%g", v)
+				p.Text(v+0.3, 0.8, label, "mono", 0.8, color, opacity)
+				p.Text(0.3, v+0.5, label, "mono", 0.8, color, opacity)
+			}
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package deckgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// scalecoords maps a space separated list of coordinates with f.
+func scalecoords(s string, f func(float64) float64) string {
+	fields := strings.Fields(s)
+	for i, c := range fields {
+		if v, err := strconv.ParseFloat(c, 64); err == nil {
+			fields[i] = fmt.Sprintf("%.2f", f(v))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// miniature returns the elements of slide s scaled into box b of another canvas, with its
+// background as a rectangle. Images are scaled by imagescale, the ratio of the width of b
+// in pixels to that of the source canvas. Text without a color takes the slide's foreground.
+func miniature(s Slide, b Box, imagescale float64) Slide {
+	kx, ky := b.W/100, b.H/100
+	px := func(x float64) float64 { return b.X + x*kx }
+	py := func(y float64) float64 { return b.Y + y*ky }
+	fg := s.Fg
+	if fg == "" {
+		fg = "black"
+	}
+	var m Slide
+	if s.Bg != "" {
+		var bg Rect
+		bg.Xp, bg.Yp, bg.Wp, bg.Hp = b.X+b.W/2, b.Y+b.H/2, b.W, b.H
+		bg.Color, bg.Opacity = s.Bg, 100
+		m.Rect = append(m.Rect, bg)
+	}
+	for _, t := range s.Text {
+		t.Xp, t.Yp, t.Sp, t.Wp = px(t.Xp), py(t.Yp), t.Sp*kx, t.Wp*kx
+		if t.Color == "" {
+			t.Color = fg
+		}
+		m.Text = append(m.Text, t)
+	}
+	for _, l := range s.List {
+		l.Xp, l.Yp, l.Sp, l.Wp = px(l.Xp), py(l.Yp), l.Sp*kx, l.Wp*kx
+		if l.Color == "" {
+			l.Color = fg
+		}
+		m.List = append(m.List, l)
+	}
+	for _, i := range s.Image {
+		if i.Scale == 0 {
+			i.Scale = 100
+		}
+		i.Xp, i.Yp, i.Scale, i.Autoscale = px(i.Xp), py(i.Yp), i.Scale*imagescale, ""
+		m.Image = append(m.Image, i)
+	}
+	dim := func(d Dimension) Dimension {
+		d.Xp, d.Yp, d.Wp, d.Hp = px(d.Xp), py(d.Yp), d.Wp*kx, d.Hp*ky
+		return d
+	}
+	for _, e := range s.Ellipse {
+		e.Dimension = dim(e.Dimension)
+		m.Ellipse = append(m.Ellipse, e)
+	}
+	for _, r := range s.Rect {
+		r.Dimension = dim(r.Dimension)
+		m.Rect = append(m.Rect, r)
+	}
+	for _, a := range s.Arc {
+		a.Dimension = dim(a.Dimension)
+		a.Sp *= kx
+		m.Arc = append(m.Arc, a)
+	}
+	for _, l := range s.Line {
+		l.Xp1, l.Yp1, l.Xp2, l.Yp2, l.Sp = px(l.Xp1), py(l.Yp1), px(l.Xp2), py(l.Yp2), l.Sp*kx
+		m.Line = append(m.Line, l)
+	}
+	for _, c := range s.Curve {
+		c.Xp1, c.Yp1, c.Xp2, c.Yp2, c.Xp3, c.Yp3 = px(c.Xp1), py(c.Yp1), px(c.Xp2), py(c.Yp2), px(c.Xp3), py(c.Yp3)
+		c.Sp *= kx
+		m.Curve = append(m.Curve, c)
+	}
+	for _, g := range s.Polygon {
+		g.XC, g.YC = scalecoords(g.XC, px), scalecoords(g.YC, py)
+		m.Polygon = append(m.Polygon, g)
+	}
+	for _, g := range s.Polyline {
+		g.XC, g.YC = scalecoords(g.XC, px), scalecoords(g.YC, py)
+		g.Sp *= kx
+		m.Polyline = append(m.Polyline, g)
+	}
+	return m
+}
+
+// addelements appends the elements of src to dst.
+func addelements(dst *Slide, src Slide) {
+	dst.List = append(dst.List, src.List...)
+	dst.Text = append(dst.Text, src.Text...)
+	dst.Image = append(dst.Image, src.Image...)
+	dst.Ellipse = append(dst.Ellipse, src.Ellipse...)
+	dst.Line = append(dst.Line, src.Line...)
+	dst.Rect = append(dst.Rect, src.Rect...)
+	dst.Curve = append(dst.Curve, src.Curve...)
+	dst.Arc = append(dst.Arc, src.Arc...)
+	dst.Polygon = append(dst.Polygon, src.Polygon...)
+	dst.Polyline = append(dst.Polyline, src.Polyline...)
+}
+
+// frame returns lines outlining box b.
+func frame(b Box, color string) []Line {
+	l, r, bot, top := b.X, b.Right(), b.Y, b.Top()
+	return []Line{
+		{Xp1: l, Yp1: bot, Xp2: r, Yp2: bot, Sp: 0.1, Color: color, Opacity: 100},
+		{Xp1: r, Yp1: bot, Xp2: r, Yp2: top, Sp: 0.1, Color: color, Opacity: 100},
+		{Xp1: r, Yp1: top, Xp2: l, Yp2: top, Sp: 0.1, Color: color, Opacity: 100},
+		{Xp1: l, Yp1: top, Xp2: l, Yp2: bot, Sp: 0.1, Color: color, Opacity: 100},
+	}
+}
+
+// canvasof returns the canvas dimensions of a deck, defaulting to 1024x768.
+func canvasof(d *Deck) (int, int) {
+	if d.Canvas.Width <= 0 || d.Canvas.Height <= 0 {
+		return 1024, 768
+	}
+	return d.Canvas.Width, d.Canvas.Height
+}
+
+// Handout makes a deck for printing from src, on portrait pages, with perPage slides on each,
+// scaled down, one above the other, each beside ruled lines for notes. The elements of the
+// slides are rescaled into their places on the page; slide backgrounds become rectangles.
+func Handout(src *Deck, perPage int) *Deck {
+	if perPage < 1 {
+		perPage = 1
+	}
+	sw, sh := canvasof(src)
+	pw, ph := sw, sh
+	if pw > ph {
+		pw, ph = ph, pw
+	}
+	out := &Deck{Title: src.Title, Creator: src.Creator, Subject: src.Subject, Publisher: src.Publisher,
+		Description: src.Description, Date: src.Date, Canvas: canvas{Width: pw, Height: ph}}
+	// height of a miniature, in page height percentages, per width percentage
+	aspect := float64(pw) / float64(ph) * float64(sh) / float64(sw)
+	rh := 90 / float64(perPage)
+	w := 45.0
+	if w*aspect > rh*0.9 {
+		w = rh * 0.9 / aspect
+	}
+	h := w * aspect
+	for i, s := range src.Slide {
+		if i%perPage == 0 {
+			out.Slide = append(out.Slide, Slide{Bg: "white", Fg: "black"})
+		}
+		page := &out.Slide[len(out.Slide)-1]
+		top := 95 - rh*float64(i%perPage)
+		b := Box{X: 5, Y: top - (rh+h)/2, W: w, H: h}
+		addelements(page, miniature(s, b, w/100*float64(pw)/float64(sw)))
+		page.Line = append(page.Line, frame(b, "gray")...)
+		for y := b.Top() - 4; y > b.Y; y -= 4 {
+			page.Line = append(page.Line, Line{Xp1: w + 10, Yp1: y, Xp2: 95, Yp2: y, Sp: 0.1, Color: "lightgray", Opacity: 100})
+		}
+	}
+	return out
+}
+
+// Sorter makes an overview deck from src, with each slide shown as a numbered miniature
+// in a grid of cols by rows, continuing on further slides as needed.
+func Sorter(src *Deck, cols, rows int) *Deck {
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	sw, sh := canvasof(src)
+	out := &Deck{Title: src.Title, Creator: src.Creator, Subject: src.Subject, Publisher: src.Publisher,
+		Description: src.Description, Date: src.Date, Canvas: canvas{Width: sw, Height: sh}}
+	// cells fill 90% of the canvas; miniatures keep the aspect ratio, as the canvas is the same
+	cw, ch := 90/float64(cols), 90/float64(rows)
+	w := cw * 0.9
+	if h := ch * 0.8; w > h {
+		w = h
+	}
+	per := cols * rows
+	for i, s := range src.Slide {
+		if i%per == 0 {
+			out.Slide = append(out.Slide, Slide{Bg: "white", Fg: "black"})
+		}
+		page := &out.Slide[len(out.Slide)-1]
+		c, r := i%per%cols, i%per/cols
+		cx, top := 5+cw*(float64(c)+0.5), 95-ch*float64(r)
+		b := Box{X: cx - w/2, Y: top - w - ch*0.05, W: w, H: w}
+		addelements(page, miniature(s, b, w/100))
+		page.Line = append(page.Line, frame(b, "gray")...)
+		var n Text
+		n.Xp, n.Yp, n.Sp = cx, b.Y-ch*0.08, ch*0.05
+		n.Align, n.Color, n.Opacity, n.Font = "center", "gray", 100, "sans"
+		n.Tdata = strconv.Itoa(i + 1)
+		page.Text = append(page.Text, n)
+	}
+	return out
+}
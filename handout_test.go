@@ -0,0 +1,117 @@
+package deckgen
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testdeck returns a deck of n slides on a 1024x768 canvas, each with a background
+// and a centered text of its number.
+func testdeck(t *testing.T, n int) *Deck {
+	var b strings.Builder
+	b.WriteString(`<deck><canvas width="1024" height="768"/>`)
+	for i := 0; i < n; i++ {
+		b.WriteString(`<slide bg="navy" fg="white"><text xp="50" yp="50" sp="4">slide</text><rect xp="50" yp="50" wp="100" hp="10" color="red"/></slide>`)
+	}
+	b.WriteString(`</deck>`)
+	d, err := ReadDeck(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestHandout(t *testing.T) {
+	tests := []struct {
+		slides, perPage int
+		pages           int
+	}{
+		{5, 3, 2},
+		{3, 3, 1},
+		{2, 0, 2},
+		{1, 6, 1},
+	}
+	for _, test := range tests {
+		h := Handout(testdeck(t, test.slides), test.perPage)
+		if h.Canvas.Width != 768 || h.Canvas.Height != 1024 {
+			t.Errorf("Handout(%d, %d): canvas %dx%d, want portrait 768x1024", test.slides, test.perPage, h.Canvas.Width, h.Canvas.Height)
+		}
+		if len(h.Slide) != test.pages {
+			t.Fatalf("Handout(%d, %d): %d pages, want %d", test.slides, test.perPage, len(h.Slide), test.pages)
+		}
+		texts := 0
+		for _, page := range h.Slide {
+			for _, tx := range page.Text {
+				texts++
+				if tx.Color != "white" {
+					t.Errorf("Handout(%d, %d): text color %q, want the slide foreground", test.slides, test.perPage, tx.Color)
+				}
+				if tx.Xp < 5 || tx.Xp > 50 || tx.Sp >= 4 {
+					t.Errorf("Handout(%d, %d): text at x %g, size %g, not a miniature in the left column", test.slides, test.perPage, tx.Xp, tx.Sp)
+				}
+			}
+			// each miniature has a background, its rectangle, a frame and ruled lines beside it
+			bgs := 0
+			for _, r := range page.Rect {
+				if r.Color == "navy" {
+					bgs++
+				}
+			}
+			if bgs != len(page.Text) {
+				t.Errorf("Handout(%d, %d): %d backgrounds for %d miniatures", test.slides, test.perPage, bgs, len(page.Text))
+			}
+			ruled := 0
+			for _, l := range page.Line {
+				if l.Color == "lightgray" {
+					ruled++
+				}
+			}
+			if ruled == 0 {
+				t.Errorf("Handout(%d, %d): no lines for notes", test.slides, test.perPage)
+			}
+		}
+		if texts != test.slides {
+			t.Errorf("Handout(%d, %d): %d miniatures, want %d", test.slides, test.perPage, texts, test.slides)
+		}
+	}
+}
+
+func TestSorter(t *testing.T) {
+	tests := []struct {
+		slides, cols, rows int
+		pages              int
+	}{
+		{5, 2, 2, 2},
+		{4, 2, 2, 1},
+		{3, 0, 0, 3},
+		{12, 4, 3, 1},
+	}
+	for _, test := range tests {
+		s := Sorter(testdeck(t, test.slides), test.cols, test.rows)
+		if s.Canvas.Width != 1024 || s.Canvas.Height != 768 {
+			t.Errorf("Sorter(%d, %d, %d): canvas %dx%d, want 1024x768", test.slides, test.cols, test.rows, s.Canvas.Width, s.Canvas.Height)
+		}
+		if len(s.Slide) != test.pages {
+			t.Fatalf("Sorter(%d, %d, %d): %d pages, want %d", test.slides, test.cols, test.rows, len(s.Slide), test.pages)
+		}
+		var numbers []string
+		for _, page := range s.Slide {
+			for _, tx := range page.Text {
+				if tx.Tdata != "slide" {
+					numbers = append(numbers, tx.Tdata)
+				}
+				if tx.Xp < 5 || tx.Xp > 95 || tx.Yp < 5 || tx.Yp > 95 {
+					t.Errorf("Sorter(%d, %d, %d): text %q at (%g, %g), outside the grid", test.slides, test.cols, test.rows, tx.Tdata, tx.Xp, tx.Yp)
+				}
+			}
+		}
+		var want []string
+		for i := 1; i <= test.slides; i++ {
+			want = append(want, strconv.Itoa(i))
+		}
+		if strings.Join(numbers, " ") != strings.Join(want, " ") {
+			t.Errorf("Sorter(%d, %d, %d): numbers %v, want %v", test.slides, test.cols, test.rows, numbers, want)
+		}
+	}
+}
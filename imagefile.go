@@ -0,0 +1,161 @@
+package deckgen
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders for DecodeConfig
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ImageOptions are optional attributes of images placed by ImageFile.
+type ImageOptions struct {
+	Link      string  // link followed when the image is clicked
+	Caption   string  // caption drawn below the image
+	Scale     float64 // scale, in percent of the natural size (default 100)
+	Autoscale bool    // scale the image to fit the canvas
+	Embed     bool    // include the image in the deck as a data URI
+}
+
+// imageclient fetches remote images.
+var imageclient = &http.Client{Timeout: 30 * time.Second}
+
+// SetAssetDir sets the directory in which ImageFile caches remote images.
+// With no asset directory, remote images are referenced by their URL.
+func (p *DeckGen) SetAssetDir(dir string) {
+	p.assetdir = dir
+}
+
+// isremote reports whether an image name is an http or https URL.
+func isremote(name string) bool {
+	return strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://")
+}
+
+// imagesize reads the pixel dimensions of an image file.
+func imagesize(name string) (int, int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	c, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %v", name, err)
+	}
+	return c.Width, c.Height, nil
+}
+
+// fetchimage reads a remote image.
+func fetchimage(url string) ([]byte, error) {
+	resp, err := imageclient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// remoteimage fetches a remote image, and returns the name to reference and its dimensions.
+// If there is an asset directory, the image is cached there, named by a hash of the URL,
+// and later calls use the cached copy.
+func (p *DeckGen) remoteimage(url string) (string, int, int, error) {
+	var cached string
+	if p.assetdir != "" {
+		sum := sha1.Sum([]byte(url))
+		ext := filepath.Ext(strings.SplitN(url, "?", 2)[0])
+		if len(ext) > 5 {
+			ext = ""
+		}
+		cached = filepath.Join(p.assetdir, hex.EncodeToString(sum[:8])+ext)
+		if w, h, err := imagesize(cached); err == nil {
+			return cached, w, h, nil
+		}
+	}
+	data, err := fetchimage(url)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	c, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("%s: %v", url, err)
+	}
+	if cached == "" {
+		return url, c.Width, c.Height, nil
+	}
+	if err := os.MkdirAll(p.assetdir, 0755); err != nil {
+		return "", 0, 0, err
+	}
+	if err := os.WriteFile(cached, data, 0644); err != nil {
+		return "", 0, 0, err
+	}
+	return cached, c.Width, c.Height, nil
+}
+
+// embedimage reads a local or remote image, and returns it as a data URI, with its dimensions.
+func embedimage(name string) (string, int, int, error) {
+	var data []byte
+	var err error
+	if isremote(name) {
+		data, err = fetchimage(name)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return "", 0, 0, err
+	}
+	c, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("%s: %v", name, err)
+	}
+	uri := "data:image/" + format + ";base64," + base64.StdEncoding.EncodeToString(data)
+	return uri, c.Width, c.Height, nil
+}
+
+// imagesource returns the name to reference for an image, embedded or cached as needed, and its dimensions.
+func (p *DeckGen) imagesource(name string, embed bool) (string, int, int, error) {
+	switch {
+	case embed:
+		return embedimage(name)
+	case isremote(name):
+		return p.remoteimage(name)
+	}
+	w, h, err := imagesize(name)
+	return name, w, h, err
+}
+
+// ImageFile places the named image, centered at (x,y), with its dimensions read from the file.
+// GIF, JPEG and PNG images are supported. The name may be an http or https URL;
+// see SetAssetDir for caching remote images. With the Embed option, the image
+// content is included in the deck, so that it has no external assets.
+func (p *DeckGen) ImageFile(x, y float64, name string, opts ...ImageOptions) error {
+	var o ImageOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	name, w, h, err := p.imagesource(name, o.Embed)
+	if err != nil {
+		return err
+	}
+	i := Image{Width: w, Height: h, Name: name, Caption: o.Caption, Scale: o.Scale}
+	if o.Autoscale {
+		i.Autoscale = "on"
+	}
+	i.Xp = x
+	i.Yp = y
+	i.CommonAttr.Link = o.Link
+	p.image(i)
+	return nil
+}
@@ -0,0 +1,221 @@
+package deckgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// kubeobject holds the fields of Kubernetes nodes, pods and deployments used for status slides.
+type kubeobject struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas   *int   `json:"replicas"`
+		NodeName   string `json:"nodeName"`
+		Containers []struct {
+			Resources struct {
+				Requests map[string]string `json:"requests"`
+			} `json:"resources"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase      string `json:"phase"`
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+		Allocatable       map[string]string `json:"allocatable"`
+		ReadyReplicas     int               `json:"readyReplicas"`
+		UpdatedReplicas   int               `json:"updatedReplicas"`
+		AvailableReplicas int               `json:"availableReplicas"`
+		ContainerStatuses []struct {
+			RestartCount int `json:"restartCount"`
+		} `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// kubelist is a kubectl list of objects of any kind.
+type kubelist struct {
+	Items []kubeobject `json:"items"`
+}
+
+// condition returns the status of the named condition, or "Unknown".
+func (o kubeobject) condition(t string) string {
+	for _, c := range o.Status.Conditions {
+		if c.Type == t {
+			return c.Status
+		}
+	}
+	return "Unknown"
+}
+
+// quantitysuffixes are the multipliers of Kubernetes resource quantity suffixes.
+var quantitysuffixes = []struct {
+	suffix string
+	mult   float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+	{"m", 1e-3}, {"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12},
+}
+
+// parsequantity converts a Kubernetes resource quantity such as "250m" or "1Gi" to a number.
+func parsequantity(q string) float64 {
+	for _, s := range quantitysuffixes {
+		if strings.HasSuffix(q, s.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(q, s.suffix), 64)
+			if err != nil {
+				return 0
+			}
+			return v * s.mult
+		}
+	}
+	v, _ := strconv.ParseFloat(q, 64)
+	return v
+}
+
+// KubeSlides reads kubectl JSON output (for example, from
+// "kubectl get nodes,pods,deployments -A -o json"), and generates slides of
+// node health, pod phases and restarts, deployment status, and the share of
+// each node's allocatable CPU and memory requested by its pods.
+func (p *DeckGen) KubeSlides(r io.Reader) error {
+	var list kubelist
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return err
+	}
+	t := p.themeof(nil)
+	var nodes, pods, deployments []kubeobject
+	for _, o := range list.Items {
+		switch o.Kind {
+		case "Node":
+			nodes = append(nodes, o)
+		case "Pod":
+			pods = append(pods, o)
+		case "Deployment":
+			deployments = append(deployments, o)
+		}
+	}
+	if len(nodes)+len(pods)+len(deployments) == 0 {
+		return fmt.Errorf("no nodes, pods or deployments found")
+	}
+
+	if len(nodes) > 0 {
+		rows := make([][]string, len(nodes))
+		for i, n := range nodes {
+			rows[i] = []string{n.Metadata.Name, n.condition("Ready"),
+				n.Status.Allocatable["cpu"], n.Status.Allocatable["memory"], n.Status.Allocatable["pods"]}
+		}
+		p.reportslide(fmt.Sprintf("Nodes (%d)", len(nodes)))
+		p.Table(10, 82, 80, []string{"Node", "Ready", "CPU", "Memory", "Pods"}, rows,
+			TableStyle{Align: []string{"begin", "center", "end", "end", "end"}})
+		p.EndSlide()
+	}
+
+	if len(pods) > 0 {
+		phases := map[string]int{}
+		for _, o := range pods {
+			phases[o.Status.Phase]++
+		}
+		names := sortedcounts(phases)
+		values := make([]float64, len(names))
+		for i, n := range names {
+			values[i] = float64(phases[n])
+		}
+		type restarts struct {
+			name string
+			n    int
+		}
+		var rs []restarts
+		for _, o := range pods {
+			n := 0
+			for _, cs := range o.Status.ContainerStatuses {
+				n += cs.RestartCount
+			}
+			if n > 0 {
+				rs = append(rs, restarts{o.Metadata.Namespace + "/" + o.Metadata.Name, n})
+			}
+		}
+		sort.Slice(rs, func(i, j int) bool { return rs[i].n > rs[j].n })
+		if len(rs) > 10 {
+			rs = rs[:10]
+		}
+		p.reportslide(fmt.Sprintf("Pods (%d)", len(pods)))
+		p.hbars(Box{X: 5, Y: 10, W: 40, H: 70}, names, values, t.Accent)
+		rows := make([][]string, len(rs))
+		for i, r := range rs {
+			rows[i] = []string{r.name, strconv.Itoa(r.n)}
+		}
+		if len(rows) == 0 {
+			rows = [][]string{{"no restarts", ""}}
+		}
+		p.Table(52, 80, 43, []string{"Pod", "Restarts"}, rows, TableStyle{Size: 1.2, Align: []string{"begin", "end"}})
+		p.EndSlide()
+	}
+
+	if len(deployments) > 0 {
+		rows := make([][]string, len(deployments))
+		for i, d := range deployments {
+			want := 1
+			if d.Spec.Replicas != nil {
+				want = *d.Spec.Replicas
+			}
+			rows[i] = []string{d.Metadata.Namespace + "/" + d.Metadata.Name,
+				fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, want),
+				strconv.Itoa(d.Status.UpdatedReplicas), strconv.Itoa(d.Status.AvailableReplicas)}
+		}
+		p.reportslide(fmt.Sprintf("Deployments (%d)", len(deployments)))
+		p.Table(10, 82, 80, []string{"Deployment", "Ready", "Up-to-date", "Available"}, rows,
+			TableStyle{Align: []string{"begin", "end", "end", "end"}})
+		p.EndSlide()
+	}
+
+	if len(nodes) > 0 && len(pods) > 0 {
+		cpu, mem := map[string]float64{}, map[string]float64{}
+		for _, o := range pods {
+			for _, c := range o.Spec.Containers {
+				cpu[o.Spec.NodeName] += parsequantity(c.Resources.Requests["cpu"])
+				mem[o.Spec.NodeName] += parsequantity(c.Resources.Requests["memory"])
+			}
+		}
+		names := make([]string, len(nodes))
+		cpupct := make([]float64, len(nodes))
+		mempct := make([]float64, len(nodes))
+		for i, n := range nodes {
+			names[i] = n.Metadata.Name
+			if a := parsequantity(n.Status.Allocatable["cpu"]); a > 0 {
+				cpupct[i] = float64(int(cpu[n.Metadata.Name] / a * 100))
+			}
+			if a := parsequantity(n.Status.Allocatable["memory"]); a > 0 {
+				mempct[i] = float64(int(mem[n.Metadata.Name] / a * 100))
+			}
+		}
+		p.reportslide("Requested Resources (% of allocatable)")
+		p.TextMid(27.5, 80, "CPU", t.Font, 2, t.Muted)
+		p.hbars(Box{X: 5, Y: 10, W: 42, H: 65}, names, cpupct, t.Accent)
+		p.TextMid(72.5, 80, "Memory", t.Font, 2, t.Muted)
+		p.hbars(Box{X: 50, Y: 10, W: 42, H: 65}, names, mempct, "seagreen")
+		p.EndSlide()
+	}
+	return nil
+}
+
+// sortedcounts returns the keys of a count map, largest count first.
+func sortedcounts(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
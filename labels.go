@@ -0,0 +1,93 @@
+package deckgen
+
+import "math"
+
+// Label is a text label anchored at (X, Y), typically naming a data point.
+type Label struct {
+	X, Y  float64
+	Text  string
+	Font  string
+	Size  float64
+	Color string
+	Align string // begin, center or end
+}
+
+// labeltries is the number of candidate positions tried for each label.
+const labeltries = 24
+
+// labelbox returns the estimated box of a label at its anchor.
+func (p *DeckGen) labelbox(l Label) Box {
+	t := Text{}
+	t.Xp, t.Yp, t.Sp, t.Font, t.Align, t.Tdata = l.X, l.Y, l.Size, l.Font, l.Align, l.Text
+	return p.Bounds(t)
+}
+
+// collides reports whether b overlaps any of the boxes in set, or falls off the canvas.
+func collides(b Box, set []Box) bool {
+	if b.X < 0 || b.Y < 0 || b.Right() > 100 || b.Top() > 100 {
+		return true
+	}
+	for _, o := range set {
+		if b.Overlaps(o) {
+			return true
+		}
+	}
+	return false
+}
+
+// labeloffsets returns candidate (dx, dy) nudges, nearest first,
+// alternating above, below, right and left of the anchor.
+func labeloffsets(w, h float64) [][2]float64 {
+	offsets := [][2]float64{{0, 0}}
+	for i := 1; len(offsets) < labeltries; i++ {
+		n := float64(i)
+		offsets = append(offsets,
+			[2]float64{0, n * h},
+			[2]float64{0, -n * h},
+			[2]float64{n * w / 2, 0},
+			[2]float64{-n * w / 2, 0},
+			[2]float64{n * w / 2, n * h},
+			[2]float64{-n * w / 2, -n * h},
+		)
+	}
+	return offsets
+}
+
+// PlaceLabels emits labels, nudging each one away from its anchor as needed so that
+// it does not overlap previously placed labels or any of the obstacles.
+// If leader is not empty, a thin line of that color connects displaced labels to their anchors.
+// The boxes of the placed labels are returned.
+func (p *DeckGen) PlaceLabels(labels []Label, leader string, obstacles ...Box) []Box {
+	placed := make([]Box, 0, len(labels)+len(obstacles))
+	placed = append(placed, obstacles...)
+	result := make([]Box, len(labels))
+	for i, l := range labels {
+		b := p.labelbox(l)
+		best := l
+		for _, off := range labeloffsets(b.W, b.H) {
+			c := l
+			c.X += off[0]
+			c.Y += off[1]
+			if cb := p.labelbox(c); !collides(cb, placed) {
+				best, b = c, cb
+				break
+			}
+		}
+		placed = append(placed, b)
+		result[i] = b
+		if leader != "" && (best.X != l.X || best.Y != l.Y) {
+			ex := math.Max(b.X, math.Min(l.X, b.Right()))
+			ey := math.Max(b.Y, math.Min(l.Y, b.Top()))
+			p.Line(l.X, l.Y, ex, ey, 0.1, leader, 50)
+		}
+		switch best.Align {
+		case "center", "middle", "mid", "c":
+			p.TextMid(best.X, best.Y, best.Text, best.Font, best.Size, best.Color)
+		case "end", "right", "e":
+			p.TextEnd(best.X, best.Y, best.Text, best.Font, best.Size, best.Color)
+		default:
+			p.Text(best.X, best.Y, best.Text, best.Font, best.Size, best.Color)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package deckgen
+
+import (
+	"bytes"
+	"io"
+	"sort"
+)
+
+// Layers, in back-to-front order. Elements outside of Background and Foreground
+// are on the content layer, unless another is selected with Layer.
+const (
+	BackgroundLayer = -1
+	ContentLayer    = 0
+	ForegroundLayer = 1
+)
+
+// out returns the destination of element markup: within a slide, the buffer of the current layer.
+func (p *DeckGen) out() io.Writer {
+	if !p.inslide {
+		return p.dest
+	}
+	if p.layers == nil {
+		p.layers = map[int]*bytes.Buffer{}
+	}
+	buf, ok := p.layers[p.layer]
+	if !ok {
+		buf = new(bytes.Buffer)
+		p.layers[p.layer] = buf
+	}
+	return buf
+}
+
+// inlayer runs fn with elements placed on layer n.
+func (p *DeckGen) inlayer(n int, fn func(*DeckGen)) {
+	save := p.layer
+	p.layer = n
+	fn(p)
+	p.layer = save
+}
+
+// Layer places subsequent elements of the current slide on layer n. At the end of the slide,
+// layers are emitted in ascending order, so that elements on higher layers are drawn in
+// front of those on lower layers, regardless of the order of the calls. Each slide starts
+// on ContentLayer.
+func (p *DeckGen) Layer(n int) {
+	p.layer = n
+}
+
+// Background runs fn with its elements placed behind the slide's other elements,
+// regardless of when it is called, for example for gridlines or highlight washes.
+func (p *DeckGen) Background(fn func(*DeckGen)) {
+	p.inlayer(BackgroundLayer, fn)
+}
+
+// Foreground runs fn with its elements placed in front of the slide's other elements,
+// regardless of when it is called, for example for annotations.
+func (p *DeckGen) Foreground(fn func(*DeckGen)) {
+	p.inlayer(ForegroundLayer, fn)
+}
+
+// flushlayers writes the buffered layers, back to front, and clears them.
+func (p *DeckGen) flushlayers() {
+	order := make([]int, 0, len(p.layers))
+	for n := range p.layers {
+		order = append(order, n)
+	}
+	sort.Ints(order)
+	for _, n := range order {
+		p.layers[n].WriteTo(p.dest)
+	}
+	p.layers = nil
+	p.layer = ContentLayer
+	p.inslide = false
+}
@@ -0,0 +1,179 @@
+package deckgen
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// NestedItem is an item of an outline, at an indent level starting at 0.
+type NestedItem struct {
+	Text  string
+	Level int
+}
+
+// nestedratio is the size of each outline level relative to the one above.
+const nestedratio = 0.85
+
+// ListNested makes an outline from items with indent levels, starting at (x,y). Each level is
+// indented by twice the size of the top level, and set smaller; even levels are bulleted,
+// and odd levels dashed. Consecutive items at the same level are made as one list.
+// Wrap is the width of the outline (0 for no wrapping). With automatic pagination
+// (see SetAutoPaginate), the outline continues on new slides as needed.
+// The y below the outline, on its last slide, is returned.
+func (p *DeckGen) ListNested(x, y, size, spacing, wrap float64, items []NestedItem, font, color string) float64 {
+	size = p.sizeof(size)
+	fresh := false // at the top of a continuation slide
+	for i := 0; i < len(items); {
+		level := items[i].Level
+		if level < 0 {
+			level = 0
+		}
+		ls := size * math.Pow(nestedratio, float64(level))
+		indent := float64(level) * size * 2
+		w := 0.0
+		if wrap > 0 {
+			w = math.Max(wrap-indent, ls)
+		}
+		step := p.hpct(ls * leading(spacing))
+		j, next := i, y
+		var texts []string
+		for j < len(items) && items[j].Level == items[i].Level {
+			n := 1
+			if w > 0 {
+				n = len(wraplines(items[j].Text, p.fontof(font), ls, w))
+			}
+			if p.paginating(0) && next-step*float64(n-1) < p.paginate && (len(texts) > 0 || !fresh) {
+				break
+			}
+			texts = append(texts, items[j].Text)
+			next -= step * float64(n)
+			j++
+		}
+		if len(texts) > 0 {
+			ltype := "bullet"
+			if level%2 == 1 {
+				ltype = "plain"
+				for k, t := range texts {
+					texts[k] = "– " + t
+				}
+			}
+			p.List(x+indent, y, ls, spacing, w, texts, ltype, font, color)
+			y = next
+		}
+		fresh = false
+		if j < len(items) && items[j].Level == items[i].Level {
+			p.continueslide()
+			y, fresh = continuedy, true
+		}
+		i = j
+	}
+	return y
+}
+
+// roman returns n as a lower case Roman numeral.
+func roman(n int) string {
+	if n <= 0 {
+		return fmt.Sprint(n)
+	}
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	numerals := []string{"m", "cm", "d", "cd", "c", "xc", "l", "xl", "x", "ix", "v", "iv", "i"}
+	var b strings.Builder
+	for i, v := range values {
+		for n >= v {
+			b.WriteString(numerals[i])
+			n -= v
+		}
+	}
+	return b.String()
+}
+
+// alpha returns n as a lower case letter sequence: a, b, ... z, aa, ab, ...
+func alpha(n int) string {
+	if n <= 0 {
+		return fmt.Sprint(n)
+	}
+	var s string
+	for n > 0 {
+		n--
+		s = string(rune('a'+n%26)) + s
+		n /= 26
+	}
+	return s
+}
+
+// numberformat formats n according to a numbering format such as "1.", "01)", "a)", "A.", "i." or "(I)":
+// the last digit run, or letter a or i, in the format is replaced by the number in that style, with
+// a run of digits giving the zero padded width. Earlier characters are kept, so that legal style
+// formats such as "2.1." number items 2.1., 2.2., and so on.
+func numberformat(format string, n int) string {
+	for i := len(format) - 1; i >= 0; i-- {
+		switch c := format[i]; c {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			j := i
+			for j > 0 && format[j-1] >= '0' && format[j-1] <= '9' {
+				j--
+			}
+			return format[:j] + fmt.Sprintf("%0*d", i+1-j, n) + format[i+1:]
+		case 'a', 'A', 'i', 'I':
+			num := alpha(n)
+			if c == 'i' || c == 'I' {
+				num = roman(n)
+			}
+			if c == 'A' || c == 'I' {
+				num = strings.ToUpper(num)
+			}
+			return format[:i] + num + format[i+1:]
+		}
+	}
+	return fmt.Sprintf("%d%s", n, format)
+}
+
+// ListNumbered makes a numbered list, like List, with the numbers starting at start, in format:
+// for example "1." (the default), "01)", "a)", "A.", "i.", "(I)" or "2.1.". Starting beyond 1
+// continues a list from a previous slide.
+func (p *DeckGen) ListNumbered(x, y, size, spacing, wrap float64, items []string, start int, format, font, color string) {
+	if format == "" {
+		format = "1."
+	}
+	numbered := make([]string, len(items))
+	for i, s := range items {
+		numbered[i] = numberformat(format, start+i) + " " + s
+	}
+	p.List(x, y, size, spacing, wrap, numbered, "plain", font, color)
+}
+
+// ChecklistItem is an item of a checklist.
+type ChecklistItem struct {
+	Text string
+	Done bool
+}
+
+// Checklist makes a list of items with checkboxes, starting with the baseline of the first item
+// at (x,y). Done items are checked, dimmed and struck through. The y below the list is returned.
+func (p *DeckGen) Checklist(x, y, size float64, items []ChecklistItem, font, color string) float64 {
+	size = p.sizeof(size)
+	font = p.fontof(font)
+	step := p.hpct(size * leading(0))
+	box := size * 0.8
+	thick := size / 12
+	for _, item := range items {
+		bx, by := x, y-p.hpct(size*0.1)
+		bh := p.hpct(box)
+		p.Polyline([]float64{bx, bx + box, bx + box, bx, bx}, []float64{by, by, by + bh, by + bh, by}, thick, color, 100)
+		tx := x + box + size*0.6
+		if item.Done {
+			p.Polyline(
+				[]float64{bx + box*0.2, bx + box*0.42, bx + box*0.85},
+				[]float64{by + bh*0.5, by + bh*0.22, by + bh*0.85},
+				thick*1.5, color, 100)
+			p.Text(tx, y, item.Text, font, size, color, 50)
+			ly := y + p.hpct(size*0.3)
+			p.Line(tx, ly, tx+textwidth(item.Text, font, size), ly, thick, color, 50)
+		} else {
+			p.Text(tx, y, item.Text, font, size, color)
+		}
+		y -= step
+	}
+	return y
+}
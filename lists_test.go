@@ -0,0 +1,52 @@
+package deckgen
+
+import "testing"
+
+func TestNumberFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		n      int
+		want   string
+	}{
+		{"1.", 3, "3."},
+		{"01)", 7, "07)"},
+		{"01)", 123, "123)"},
+		{"a)", 1, "a)"},
+		{"a)", 28, "ab)"},
+		{"A.", 26, "Z."},
+		{"i.", 4, "iv."},
+		{"(I)", 1994, "(MCMXCIV)"},
+		{"2.1.", 5, "2.5."},
+		{"-", 2, "2-"},
+		{"a)", 0, "0)"},
+		{"i.", -1, "-1."},
+	}
+	for _, test := range tests {
+		if got := numberformat(test.format, test.n); got != test.want {
+			t.Errorf("numberformat(%q, %d) = %q, want %q", test.format, test.n, got, test.want)
+		}
+	}
+}
+
+func TestRomanAlpha(t *testing.T) {
+	tests := []struct {
+		n            int
+		roman, alpha string
+	}{
+		{1, "i", "a"},
+		{9, "ix", "i"},
+		{26, "xxvi", "z"},
+		{27, "xxvii", "aa"},
+		{702, "dccii", "zz"},
+		{703, "dcciii", "aaa"},
+		{0, "0", "0"},
+	}
+	for _, test := range tests {
+		if got := roman(test.n); got != test.roman {
+			t.Errorf("roman(%d) = %q, want %q", test.n, got, test.roman)
+		}
+		if got := alpha(test.n); got != test.alpha {
+			t.Errorf("alpha(%d) = %q, want %q", test.n, got, test.alpha)
+		}
+	}
+}
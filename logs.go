@@ -0,0 +1,232 @@
+package deckgen
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogEvent is a structured log entry.
+type LogEvent struct {
+	Time    time.Time
+	Level   string
+	Message string
+}
+
+// log field names, in order of preference
+var (
+	logtimekeys  = []string{"time", "ts", "timestamp", "@timestamp", "t"}
+	loglevelkeys = []string{"level", "severity", "lvl", "log.level"}
+	logmsgkeys   = []string{"msg", "message", "error", "err"}
+)
+
+// logfield returns the first of the keys present in the entry.
+func logfield(entry map[string]interface{}, keys []string) interface{} {
+	for _, k := range keys {
+		if v, ok := entry[k]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// logtime converts an RFC 3339 string or Unix seconds to a time.
+func logtime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+			if tm, err := time.Parse(layout, t); err == nil {
+				return tm, true
+			}
+		}
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return logtime(f)
+		}
+	case float64:
+		sec, frac := math.Modf(t)
+		return time.Unix(int64(sec), int64(frac*1e9)), true
+	}
+	return time.Time{}, false
+}
+
+// ParseLogs reads JSON lines logs, skipping lines that are not JSON objects.
+// Time, level and message fields are recognised under their common names.
+func ParseLogs(r io.Reader) ([]LogEvent, error) {
+	var events []LogEvent
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var entry map[string]interface{}
+		if json.Unmarshal(sc.Bytes(), &entry) != nil {
+			continue
+		}
+		e := LogEvent{}
+		e.Time, _ = logtime(logfield(entry, logtimekeys))
+		e.Level = strings.ToLower(fmt.Sprint(logfield(entry, loglevelkeys)))
+		if e.Level == "<nil>" {
+			e.Level = "unknown"
+		}
+		if m := logfield(entry, logmsgkeys); m != nil {
+			e.Message = fmt.Sprint(m)
+		}
+		events = append(events, e)
+	}
+	return events, sc.Err()
+}
+
+// iserror reports whether a level denotes an error.
+func iserror(level string) bool {
+	switch level {
+	case "error", "err", "fatal", "panic", "critical", "crit", "emergency", "alert":
+		return true
+	}
+	return false
+}
+
+// vbars draws vertical bars within area, scaled to the largest value.
+func (p *DeckGen) vbars(area Box, values []float64, color string) {
+	n := len(values)
+	if n == 0 {
+		return
+	}
+	largest := 0.0
+	for _, v := range values {
+		largest = math.Max(largest, v)
+	}
+	if largest == 0 {
+		largest = 1
+	}
+	bw := area.W / float64(n)
+	for i, v := range values {
+		h := area.H * v / largest
+		if h > 0 {
+			p.Rect(area.X+bw*(float64(i)+0.5), area.Y+h/2, bw*0.85, h, color)
+		}
+	}
+	t := p.themeof(nil)
+	p.Line(area.X, area.Y, area.Right(), area.Y, 0.1, t.Muted)
+	p.TextEnd(area.X-0.5, area.Top()-p.hpct(1), strconv.FormatFloat(largest, 'g', 6, 64), t.Font, 1, t.Muted)
+}
+
+// LogSlides reads JSON lines logs and generates incident review slides: a histogram
+// of the event rate over time in the given number of bins, a table of the most
+// frequent error messages, and a pie chart of events by severity.
+func (p *DeckGen) LogSlides(r io.Reader, bins int) error {
+	events, err := ParseLogs(r)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("no log events")
+	}
+	if bins <= 0 {
+		bins = 30
+	}
+	t := p.themeof(nil)
+	var t0, t1 time.Time
+	for _, e := range events {
+		if e.Time.IsZero() {
+			continue
+		}
+		if t0.IsZero() || e.Time.Before(t0) {
+			t0 = e.Time
+		}
+		if e.Time.After(t1) {
+			t1 = e.Time
+		}
+	}
+	if !t0.IsZero() {
+		counts := make([]float64, bins)
+		errs := make([]float64, bins)
+		span := t1.Sub(t0)
+		for _, e := range events {
+			if e.Time.IsZero() {
+				continue
+			}
+			i := 0
+			if span > 0 {
+				i = int(float64(e.Time.Sub(t0)) / float64(span) * float64(bins))
+			}
+			if i >= bins {
+				i = bins - 1
+			}
+			counts[i]++
+			if iserror(e.Level) {
+				errs[i]++
+			}
+		}
+		p.reportslide(fmt.Sprintf("Event Rate (%d events)", len(events)))
+		area := Box{X: 10, Y: 15, W: 80, H: 62}
+		p.vbars(area, counts, t.Accent)
+		largest := 0.0
+		for _, c := range counts {
+			largest = math.Max(largest, c)
+		}
+		if largest > 0 {
+			for i := range errs {
+				errs[i] = errs[i] / largest * area.H
+			}
+			bw := area.W / float64(bins)
+			for i, h := range errs {
+				if h > 0 {
+					p.Rect(area.X+bw*(float64(i)+0.5), area.Y+h/2, bw*0.85, h, "orangered")
+				}
+			}
+		}
+		p.Text(area.X, area.Y-p.hpct(3), t0.Format(time.RFC3339), t.Font, 1, t.Muted)
+		p.TextEnd(area.Right(), area.Y-p.hpct(3), t1.Format(time.RFC3339), t.Font, 1, t.Muted)
+		p.TextMid(50, area.Y-p.hpct(3), fmt.Sprintf("%v per bar  ·  errors in red", (span/time.Duration(bins)).Round(time.Second)), t.Font, 1, t.Muted)
+		p.EndSlide()
+	}
+
+	messages := map[string]int{}
+	for _, e := range events {
+		if iserror(e.Level) {
+			messages[e.Message]++
+		}
+	}
+	if len(messages) > 0 {
+		top := sortedcounts(messages)
+		if len(top) > 10 {
+			top = top[:10]
+		}
+		rows := make([][]string, len(top))
+		for i, m := range top {
+			rows[i] = []string{strconv.Itoa(messages[m]), m}
+		}
+		p.reportslide("Top Errors")
+		p.Table(5, 82, 90, []string{"Count", "Message"}, rows, TableStyle{Size: 1.3, Align: []string{"end", "begin"}})
+		p.EndSlide()
+	}
+
+	levels := map[string]int{}
+	for _, e := range events {
+		levels[e.Level]++
+	}
+	names := sortedcounts(levels)
+	sort.SliceStable(names, func(i, j int) bool { return iserror(names[i]) && !iserror(names[j]) })
+	values := make([]float64, len(names))
+	colors := make([]string, len(names))
+	palette := []string{t.Accent, "seagreen", "goldenrod", t.Muted, "purple"}
+	for i, n := range names {
+		values[i] = float64(levels[n])
+		switch {
+		case iserror(n):
+			colors[i] = "orangered"
+		case n == "warn" || n == "warning":
+			colors[i] = "orange"
+		default:
+			colors[i] = palette[i%len(palette)]
+		}
+	}
+	p.reportslide("Events by Severity")
+	p.pie(50, 45, 18, names, values, colors)
+	p.EndSlide()
+	return nil
+}
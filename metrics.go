@@ -0,0 +1,76 @@
+package deckgen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Advance widths of the printable ASCII characters (space through tilde), in thousandths
+// of the font size, of the standard PostScript Helvetica and Times fonts. These built-in
+// tables measure the sans and serif fonts unless the font metrics of fontadvances are used.
+var sanswidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var serifwidths = [95]int{
+	250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278,
+	500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+	921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+	556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+	333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+	500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+}
+
+// fontadvances holds font metrics that measure the base fonts "sans", "serif" and "mono"
+// in place of the built-in tables, reporting the advance width of a rune as a fraction of
+// the font size, or false if the font has no glyph for it. Built with the gofont tag,
+// the sans and mono fonts are measured with the metrics of the Go fonts (see metrics_gofont.go).
+var fontadvances = map[string]func(rune) (float64, bool){}
+
+// basefont returns the base font by which a font name is measured: "serif", "mono" or "sans".
+func basefont(font string) string {
+	switch {
+	case strings.HasPrefix(font, "serif"):
+		return "serif"
+	case strings.HasPrefix(font, "mono"):
+		return "mono"
+	}
+	return "sans"
+}
+
+// runewidth returns the advance width of r in font, as a fraction of the font size.
+// Variants such as "serif-bold" are measured as their base font, with its font metrics
+// if there are any, and otherwise with the built-in tables. Characters not in a font's
+// metrics or tables are estimated: wide East Asian characters are a full em, others
+// an average width.
+func runewidth(r rune, font string) float64 {
+	if advance, ok := fontadvances[basefont(font)]; ok {
+		if w, ok := advance(r); ok {
+			return w
+		}
+	}
+	switch {
+	case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+		return 1
+	case strings.HasPrefix(font, "mono"):
+		return 0.6
+	case r < ' ' || r > '~':
+		return charwidth(font)
+	case strings.HasPrefix(font, "serif"):
+		return float64(serifwidths[r-' ']) / 1000
+	default:
+		return float64(sanswidths[r-' ']) / 1000
+	}
+}
+
+// StringWidth returns the width of s set in font at size, as a percentage of the canvas width,
+// measured with the font metrics used by the generator for wrapping and layout.
+// Font is a deck font name, such as "sans", "serif" or "mono", not a registered alias.
+func StringWidth(s, font string, size float64) float64 {
+	return textwidth(s, font, size)
+}
@@ -0,0 +1,38 @@
+//go:build gofont
+
+package deckgen
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Built with the gofont tag (go build -tags gofont, with golang.org/x/image in the module
+// requirements), the sans and mono fonts are measured with the metrics of the Go fonts.
+func init() {
+	fontadvances["sans"] = gofontadvance(goregular.TTF)
+	fontadvances["mono"] = gofontadvance(gomono.TTF)
+}
+
+// gofontadvance returns a function measuring the advance widths of the glyphs of the
+// TrueType font ttf, as fractions of the font size. Runes without a glyph are not measured.
+func gofontadvance(ttf []byte) func(rune) (float64, bool) {
+	f, err := sfnt.Parse(ttf)
+	if err != nil {
+		return func(rune) (float64, bool) { return 0, false }
+	}
+	return func(r rune) (float64, bool) {
+		g, err := f.GlyphIndex(nil, r)
+		if err != nil || g == 0 {
+			return 0, false
+		}
+		adv, err := f.GlyphAdvance(nil, g, fixed.I(1000), font.HintingNone)
+		if err != nil {
+			return 0, false
+		}
+		return float64(adv) / 64 / 1000, true
+	}
+}
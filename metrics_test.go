@@ -0,0 +1,31 @@
+package deckgen
+
+import "testing"
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		r    rune
+		font string
+		want float64
+	}{
+		{'W', "sans", 0.944},
+		{'W', "sans-bold", 0.944},
+		{'W', "serif", 0.944},
+		{'i', "serif-italic", 0.278},
+		{'i', "mono", 0.6},
+		{'é', "sans", 0.55},
+		{'中', "serif", 1},
+		{'x', "serif", 0.25}, // from the font metrics
+		{'y', "serif", 0.5},  // not in the font metrics
+	}
+	if len(fontadvances) > 0 {
+		t.Skip("font metrics replace the built-in tables")
+	}
+	fontadvances["serif"] = func(r rune) (float64, bool) { return 0.25, r == 'x' }
+	defer delete(fontadvances, "serif")
+	for _, test := range tests {
+		if got := runewidth(test.r, test.font); got != test.want {
+			t.Errorf("runewidth(%q, %q) = %g, want %g", test.r, test.font, got, test.want)
+		}
+	}
+}
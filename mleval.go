@@ -0,0 +1,314 @@
+package deckgen
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Confusion counts predictions against actual labels; Counts[i][j] is the number
+// of items of class Labels[i] predicted as class Labels[j].
+type Confusion struct {
+	Labels []string
+	Counts [][]int
+}
+
+// NewConfusion builds the confusion matrix of actual and predicted labels.
+// There must be a prediction for each actual label.
+func NewConfusion(actual, predicted []string) (Confusion, error) {
+	if len(actual) != len(predicted) {
+		return Confusion{}, fmt.Errorf("%d actual labels for %d predictions", len(actual), len(predicted))
+	}
+	seen := map[string]bool{}
+	var labels []string
+	for _, set := range [][]string{actual, predicted} {
+		for _, l := range set {
+			if !seen[l] {
+				seen[l] = true
+				labels = append(labels, l)
+			}
+		}
+	}
+	sort.Strings(labels)
+	index := map[string]int{}
+	for i, l := range labels {
+		index[l] = i
+	}
+	counts := make([][]int, len(labels))
+	for i := range counts {
+		counts[i] = make([]int, len(labels))
+	}
+	for i := range actual {
+		counts[index[actual[i]]][index[predicted[i]]]++
+	}
+	return Confusion{Labels: labels, Counts: counts}, nil
+}
+
+// ClassMetrics returns the precision, recall and F1 score of class i.
+func (c Confusion) ClassMetrics(i int) (precision, recall, f1 float64) {
+	tp := float64(c.Counts[i][i])
+	var predicted, actual float64
+	for j := range c.Labels {
+		predicted += float64(c.Counts[j][i])
+		actual += float64(c.Counts[i][j])
+	}
+	if predicted > 0 {
+		precision = tp / predicted
+	}
+	if actual > 0 {
+		recall = tp / actual
+	}
+	if precision+recall > 0 {
+		f1 = 2 * precision * recall / (precision + recall)
+	}
+	return
+}
+
+// Total returns the number of predictions counted.
+func (c Confusion) Total() int {
+	total := 0
+	for _, row := range c.Counts {
+		for _, n := range row {
+			total += n
+		}
+	}
+	return total
+}
+
+// Accuracy returns the fraction of correct predictions.
+func (c Confusion) Accuracy() float64 {
+	var correct, total float64
+	for i, row := range c.Counts {
+		for j, n := range row {
+			total += float64(n)
+			if i == j {
+				correct += float64(n)
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return correct / total
+}
+
+// rankscores returns the indexes of scores in descending order.
+func rankscores(scores []float64) []int {
+	idx := make([]int, len(scores))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return scores[idx[a]] > scores[idx[b]] })
+	return idx
+}
+
+// scorelengths reports an error unless there is a truth label for each score.
+func scorelengths(truth []bool, scores []float64) error {
+	if len(truth) != len(scores) {
+		return fmt.Errorf("%d truth labels for %d scores", len(truth), len(scores))
+	}
+	return nil
+}
+
+// ROC returns the false and true positive rates of the receiver operating characteristic
+// of binary truth labels and scores, and the area under the curve.
+// There must be a truth label for each score.
+func ROC(truth []bool, scores []float64) (fpr, tpr []float64, auc float64, err error) {
+	if err = scorelengths(truth, scores); err != nil {
+		return nil, nil, 0, err
+	}
+	var pos, neg float64
+	for _, t := range truth {
+		if t {
+			pos++
+		} else {
+			neg++
+		}
+	}
+	fpr, tpr = []float64{0}, []float64{0}
+	if pos == 0 || neg == 0 {
+		return fpr, tpr, 0, nil
+	}
+	idx := rankscores(scores)
+	var tp, fp float64
+	for k, i := range idx {
+		if truth[i] {
+			tp++
+		} else {
+			fp++
+		}
+		if k+1 < len(idx) && scores[idx[k+1]] == scores[i] {
+			continue // tied scores share a threshold
+		}
+		x, y := fp/neg, tp/pos
+		auc += (x - fpr[len(fpr)-1]) * (y + tpr[len(tpr)-1]) / 2
+		fpr, tpr = append(fpr, x), append(tpr, y)
+	}
+	return fpr, tpr, auc, nil
+}
+
+// PR returns the recall and precision of binary truth labels and scores at each threshold,
+// and the average precision. There must be a truth label for each score.
+func PR(truth []bool, scores []float64) (recall, precision []float64, ap float64, err error) {
+	if err = scorelengths(truth, scores); err != nil {
+		return nil, nil, 0, err
+	}
+	var pos float64
+	for _, t := range truth {
+		if t {
+			pos++
+		}
+	}
+	if pos == 0 {
+		return nil, nil, 0, nil
+	}
+	idx := rankscores(scores)
+	var tp, fp, prev float64
+	for k, i := range idx {
+		if truth[i] {
+			tp++
+		} else {
+			fp++
+		}
+		if k+1 < len(idx) && scores[idx[k+1]] == scores[i] {
+			continue
+		}
+		r, pr := tp/pos, tp/(tp+fp)
+		ap += (r - prev) * pr
+		prev = r
+		recall, precision = append(recall, r), append(precision, pr)
+	}
+	return recall, precision, ap, nil
+}
+
+// heatcolor interpolates from white to steelblue.
+func heatcolor(f float64) string {
+	f = math.Max(0, math.Min(1, f))
+	r := 255 - f*(255-70)
+	g := 255 - f*(255-130)
+	b := 255 - f*(255-180)
+	return fmt.Sprintf("rgb(%.0f,%.0f,%.0f)", r, g, b)
+}
+
+// ConfusionMatrix draws the confusion matrix of actual and predicted labels as a heatmap
+// within area, with actual classes as rows and predicted classes as columns.
+// There must be a prediction for each actual label.
+func (p *DeckGen) ConfusionMatrix(area Box, actual, predicted []string) error {
+	c, err := NewConfusion(actual, predicted)
+	if err != nil {
+		return err
+	}
+	n := len(c.Labels)
+	if n == 0 {
+		return nil
+	}
+	largest := 0
+	for _, row := range c.Counts {
+		for _, v := range row {
+			if v > largest {
+				largest = v
+			}
+		}
+	}
+	labelw := area.W * 0.2
+	grid := Box{X: area.X + labelw, Y: area.Y, W: area.W - labelw, H: area.H - p.hpct(4)}
+	cw, ch := grid.W/float64(n), grid.H/float64(n)
+	size := math.Min(1.5, cw/5)
+	t := p.themeof(nil)
+	for i, row := range c.Counts {
+		cy := grid.Top() - ch*(float64(i)+0.5)
+		p.TextEnd(grid.X-1, cy-p.hpct(size)/3, c.Labels[i], t.Font, size, t.Fg)
+		for j, v := range row {
+			cx := grid.X + cw*(float64(j)+0.5)
+			f := 0.0
+			if largest > 0 {
+				f = float64(v) / float64(largest)
+			}
+			p.Rect(cx, cy, cw*0.97, ch*0.97, heatcolor(f))
+			color := "black"
+			if f > 0.6 {
+				color = "white"
+			}
+			p.TextMid(cx, cy-p.hpct(size)/3, strconv.Itoa(v), t.Font, size, color)
+		}
+	}
+	for j, l := range c.Labels {
+		p.TextMid(grid.X+cw*(float64(j)+0.5), grid.Top()+p.hpct(1), l, t.Font, size, t.Fg)
+	}
+	p.TextMid(grid.X+grid.W/2, area.Top(), "predicted", t.Font, size*0.8, t.Muted)
+	return nil
+}
+
+// curvechart draws a curve in the unit square, scaled into area, with axis labels.
+func (p *DeckGen) curvechart(area Box, x, y []float64, xlabel, ylabel, note, color string) {
+	t := p.themeof(nil)
+	p.Line(area.X, area.Y, area.Right(), area.Y, 0.15, t.Muted)
+	p.Line(area.X, area.Y, area.X, area.Top(), 0.15, t.Muted)
+	for _, v := range []float64{0, 0.5, 1} {
+		p.TextMid(area.X+area.W*v, area.Y-p.hpct(2), strconv.FormatFloat(v, 'g', 2, 64), t.Font, 1, t.Muted)
+		p.TextEnd(area.X-0.5, area.Y+area.H*v-p.hpct(0.3), strconv.FormatFloat(v, 'g', 2, 64), t.Font, 1, t.Muted)
+	}
+	p.TextMid(area.X+area.W/2, area.Y-p.hpct(4), xlabel, t.Font, 1.2, t.Fg)
+	p.TextRotate(area.X-3, area.Y+area.H/2, ylabel, "", t.Font, 90, 1.2, t.Fg)
+	px := make([]float64, len(x))
+	py := make([]float64, len(y))
+	for i := range x {
+		px[i] = area.X + area.W*x[i]
+		py[i] = area.Y + area.H*y[i]
+	}
+	switch {
+	case len(px) >= 3:
+		p.Polyline(px, py, 0.3, color, 100)
+	case len(px) == 2:
+		p.Line(px[0], py[0], px[1], py[1], 0.3, color)
+	}
+	p.TextEnd(area.Right(), area.Top()-p.hpct(1.5), note, t.Font, 1.4, color)
+}
+
+// ROCCurve draws the ROC curve of binary truth labels and scores within area,
+// annotated with the area under the curve, which is returned.
+func (p *DeckGen) ROCCurve(area Box, truth []bool, scores []float64) (float64, error) {
+	fpr, tpr, auc, err := ROC(truth, scores)
+	if err != nil {
+		return 0, err
+	}
+	t := p.themeof(nil)
+	p.Line(area.X, area.Y, area.Right(), area.Top(), 0.1, t.Muted, 40)
+	p.curvechart(area, fpr, tpr, "false positive rate", "true positive rate", fmt.Sprintf("AUC = %.3f", auc), t.Accent)
+	return auc, nil
+}
+
+// PRCurve draws the precision-recall curve of binary truth labels and scores within area,
+// annotated with the average precision, which is returned.
+func (p *DeckGen) PRCurve(area Box, truth []bool, scores []float64) (float64, error) {
+	recall, precision, ap, err := PR(truth, scores)
+	if err != nil {
+		return 0, err
+	}
+	p.curvechart(area, recall, precision, "recall", "precision", fmt.Sprintf("AP = %.3f", ap), "orangered")
+	return ap, nil
+}
+
+// MetricsTable draws a table of precision, recall and F1 for each class, with overall accuracy,
+// whose top left corner is at (x, y) with width w.
+// There must be a prediction for each actual label.
+func (p *DeckGen) MetricsTable(x, y, w float64, actual, predicted []string) error {
+	c, err := NewConfusion(actual, predicted)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(c.Labels)+1)
+	for i, l := range c.Labels {
+		pr, rc, f1 := c.ClassMetrics(i)
+		support := 0
+		for _, v := range c.Counts[i] {
+			support += v
+		}
+		rows = append(rows, []string{l, fmt.Sprintf("%.3f", pr), fmt.Sprintf("%.3f", rc), fmt.Sprintf("%.3f", f1), strconv.Itoa(support)})
+	}
+	rows = append(rows, []string{"accuracy", "", "", fmt.Sprintf("%.3f", c.Accuracy()), strconv.Itoa(c.Total())})
+	p.Table(x, y, w, []string{"Class", "Precision", "Recall", "F1", "Support"}, rows,
+		TableStyle{Align: []string{"begin", "end", "end", "end", "end"}})
+	return nil
+}
@@ -0,0 +1,91 @@
+package deckgen
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestConfusion(t *testing.T) {
+	c, err := NewConfusion([]string{"a", "a", "b", "b", "extra"}, []string{"a", "b", "b", "b", "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "extra"}; !reflect.DeepEqual(c.Labels, want) {
+		t.Errorf("labels = %v, want %v", c.Labels, want)
+	}
+	if c.Total() != 5 {
+		t.Errorf("Total = %d, want 5", c.Total())
+	}
+	if acc := c.Accuracy(); acc != 0.6 {
+		t.Errorf("Accuracy = %g, want 0.6", acc)
+	}
+	pr, rc, _ := c.ClassMetrics(1)
+	if pr != 2.0/3 || rc != 1 {
+		t.Errorf("ClassMetrics(b) = %g, %g, want 0.667, 1", pr, rc)
+	}
+}
+
+func TestConfusionLengths(t *testing.T) {
+	tests := []struct {
+		actual, predicted []string
+	}{
+		{[]string{"a", "b"}, []string{"a"}},
+		{[]string{"a"}, []string{"a", "b"}},
+		{nil, []string{"a"}},
+	}
+	for _, test := range tests {
+		if _, err := NewConfusion(test.actual, test.predicted); err == nil {
+			t.Errorf("NewConfusion(%v, %v): no error", test.actual, test.predicted)
+		}
+		p := NewSlides(io.Discard, 1024, 768)
+		if err := p.ConfusionMatrix(Box{10, 10, 80, 80}, test.actual, test.predicted); err == nil {
+			t.Errorf("ConfusionMatrix(%v, %v): no error", test.actual, test.predicted)
+		}
+		if err := p.MetricsTable(10, 90, 80, test.actual, test.predicted); err == nil {
+			t.Errorf("MetricsTable(%v, %v): no error", test.actual, test.predicted)
+		}
+	}
+}
+
+func TestROC(t *testing.T) {
+	tests := []struct {
+		truth  []bool
+		scores []float64
+		auc    float64
+	}{
+		{[]bool{true, true, false, false}, []float64{0.9, 0.8, 0.3, 0.1}, 1},
+		{[]bool{false, false, true, true}, []float64{0.9, 0.8, 0.3, 0.1}, 0},
+		{[]bool{true, false}, []float64{0.5, 0.5}, 0.5},
+		{[]bool{true, true}, []float64{0.5, 0.4}, 0},
+	}
+	for _, test := range tests {
+		_, _, auc, err := ROC(test.truth, test.scores)
+		if err != nil || math.Abs(auc-test.auc) > 1e-9 {
+			t.Errorf("ROC(%v, %v) = %g, %v, want %g", test.truth, test.scores, auc, err, test.auc)
+		}
+	}
+	if _, _, _, err := ROC([]bool{true}, []float64{0.1, 0.2}); err == nil {
+		t.Errorf("ROC with fewer labels than scores: no error")
+	}
+}
+
+func TestPR(t *testing.T) {
+	recall, precision, ap, err := PR([]bool{true, false, true}, []float64{0.9, 0.8, 0.7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{0.5, 0.5, 1}; !reflect.DeepEqual(recall, want) {
+		t.Errorf("recall = %v, want %v", recall, want)
+	}
+	if want := []float64{1, 0.5, 2.0 / 3}; !reflect.DeepEqual(precision, want) {
+		t.Errorf("precision = %v, want %v", precision, want)
+	}
+	if want := 0.5 + 0.5*2/3; math.Abs(ap-want) > 1e-9 {
+		t.Errorf("AP = %g, want %g", ap, want)
+	}
+	if _, _, _, err := PR([]bool{true, false}, []float64{0.1}); err == nil {
+		t.Errorf("PR with more labels than scores: no error")
+	}
+}
@@ -0,0 +1,224 @@
+package deckgen
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ModEdge is a requirement from one module version to another, as listed by go mod graph.
+type ModEdge struct {
+	From, To string // module@version; the main module has no version
+}
+
+// ParseModGraph reads the output of go mod graph.
+func ParseModGraph(r io.Reader) ([]ModEdge, error) {
+	var edges []ModEdge
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) == 2 {
+			edges = append(edges, ModEdge{From: f[0], To: f[1]})
+		}
+	}
+	return edges, sc.Err()
+}
+
+// modpath splits a module@version string.
+func modpath(m string) (string, string) {
+	if i := strings.LastIndex(m, "@"); i > 0 {
+		return m[:i], m[i+1:]
+	}
+	return m, ""
+}
+
+// graphlevels assigns each node a level, the length of the shortest path from a root.
+// Roots are nodes with no incoming edges.
+func graphlevels(nodes []string, adj map[string][]string) map[string]int {
+	indeg := map[string]int{}
+	for _, targets := range adj {
+		for _, t := range targets {
+			indeg[t]++
+		}
+	}
+	level := map[string]int{}
+	var queue []string
+	for _, n := range nodes {
+		if indeg[n] == 0 {
+			level[n] = 0
+			queue = append(queue, n)
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, t := range adj[n] {
+			if _, seen := level[t]; !seen {
+				level[t] = level[n] + 1
+				queue = append(queue, t)
+			}
+		}
+	}
+	for _, n := range nodes {
+		if _, ok := level[n]; !ok {
+			level[n] = 0 // part of a cycle with no root
+		}
+	}
+	return level
+}
+
+// layergraph draws a directed graph within area, placing nodes in rows by level,
+// with labels produced by the name function. Levels beyond maxlevel are omitted.
+func (p *DeckGen) layergraph(area Box, nodes []string, adj map[string][]string, maxlevel int, name func(string) string) {
+	level := graphlevels(nodes, adj)
+	rows := map[int][]string{}
+	depth := 0
+	for _, n := range nodes {
+		l := level[n]
+		if l > maxlevel {
+			continue
+		}
+		rows[l] = append(rows[l], n)
+		if l > depth {
+			depth = l
+		}
+	}
+	pos := map[string]Point{}
+	rh := area.H / float64(depth+1)
+	for l := 0; l <= depth; l++ {
+		row := rows[l]
+		sort.Strings(row)
+		cw := area.W / float64(len(row))
+		for i, n := range row {
+			pos[n] = Point{area.X + cw*(float64(i)+0.5), area.Top() - rh*(float64(l)+0.5)}
+		}
+	}
+	th := p.themeof(nil)
+	for _, n := range nodes {
+		from, ok := pos[n]
+		if !ok {
+			continue
+		}
+		for _, t := range adj[n] {
+			if to, ok := pos[t]; ok {
+				p.Line(from.X, from.Y, to.X, to.Y, 0.1, th.Muted, 40)
+			}
+		}
+	}
+	for _, n := range nodes {
+		pt, ok := pos[n]
+		if !ok {
+			continue
+		}
+		size := 1.0
+		if len(rows[level[n]]) > 12 {
+			size = 0.7
+		}
+		p.Circle(pt.X, pt.Y, 0.8, th.Accent)
+		p.TextMid(pt.X, pt.Y-p.hpct(size)*1.8, name(n), th.Font, size, th.Fg)
+	}
+}
+
+// ModGraphSlides reads go mod graph output and generates a slide with the
+// dependency graph, and a slide with tables of the largest dependencies
+// (by number of transitive requirements) and the modules required at more than one version.
+func (p *DeckGen) ModGraphSlides(r io.Reader) error {
+	edges, err := ParseModGraph(r)
+	if err != nil {
+		return err
+	}
+	adj := map[string][]string{}
+	seen := map[string]bool{}
+	var nodes []string
+	add := func(n string) {
+		if !seen[n] {
+			seen[n] = true
+			nodes = append(nodes, n)
+		}
+	}
+	for _, e := range edges {
+		add(e.From)
+		add(e.To)
+		adj[e.From] = append(adj[e.From], e.To)
+	}
+
+	p.reportslide("Module Dependencies (" + strconv.Itoa(len(nodes)) + " modules)")
+	maxlevel := 3
+	if len(nodes) > 60 {
+		maxlevel = 1
+	}
+	p.layergraph(Box{X: 5, Y: 5, W: 90, H: 78}, nodes, adj, maxlevel, func(n string) string {
+		mp, _ := modpath(n)
+		return path.Base(mp)
+	})
+	p.EndSlide()
+
+	reach := func(n string) int {
+		visited := map[string]bool{n: true}
+		stack := []string{n}
+		for len(stack) > 0 {
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, t := range adj[c] {
+				if !visited[t] {
+					visited[t] = true
+					stack = append(stack, t)
+				}
+			}
+		}
+		return len(visited) - 1
+	}
+	level := graphlevels(nodes, adj)
+	type modsize struct {
+		name string
+		deps int
+	}
+	var sizes []modsize
+	versions := map[string][]string{}
+	for _, n := range nodes {
+		mp, v := modpath(n)
+		if v != "" {
+			versions[mp] = append(versions[mp], v)
+		}
+		if level[n] > 0 {
+			sizes = append(sizes, modsize{n, reach(n)})
+		}
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].deps != sizes[j].deps {
+			return sizes[i].deps > sizes[j].deps
+		}
+		return sizes[i].name < sizes[j].name
+	})
+	if len(sizes) > 10 {
+		sizes = sizes[:10]
+	}
+	largest := make([][]string, len(sizes))
+	for i, s := range sizes {
+		largest[i] = []string{s.name, strconv.Itoa(s.deps)}
+	}
+	var dups [][]string
+	for mp, vs := range versions {
+		if len(vs) > 1 {
+			sort.Strings(vs)
+			dups = append(dups, []string{mp, strings.Join(vs, ", ")})
+		}
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i][0] < dups[j][0] })
+	if len(dups) > 10 {
+		dups = dups[:10]
+	}
+	p.reportslide("Largest and Duplicated Dependencies")
+	style := TableStyle{Size: 1.2, Align: []string{"begin", "end"}}
+	p.Table(5, 82, 43, []string{"Module", "Transitive deps"}, largest, style)
+	if len(dups) == 0 {
+		dups = [][]string{{"none", ""}}
+	}
+	style.Align = nil
+	p.Table(52, 82, 43, []string{"Module", "Versions"}, dups, style)
+	p.EndSlide()
+	return nil
+}
@@ -0,0 +1,44 @@
+package deckgen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteNotes writes the speaker notes of the deck as a presenter script, for rehearsal,
+// or for readers who cannot see the slides. Each slide is listed with its number and title
+// (see SlideTitle), followed by its notes. The format is "text" (the default) or "markdown".
+func (d *Deck) WriteNotes(w io.Writer, format string) error {
+	var heading, empty string
+	switch format {
+	case "", "text":
+		heading, empty = "Slide %d: %s\n", "(no notes)"
+	case "markdown", "md":
+		heading, empty = "## %d. %s\n\n", "_No notes._"
+	default:
+		return fmt.Errorf("unknown notes format %q", format)
+	}
+	bw := bufio.NewWriter(w)
+	if d.Title != "" {
+		if format == "markdown" || format == "md" {
+			fmt.Fprintf(bw, "# %s\n\n", d.Title)
+		} else {
+			fmt.Fprintf(bw, "%s\n\n", d.Title)
+		}
+	}
+	for i, s := range d.Slide {
+		title := SlideTitle(s)
+		if title == "" {
+			title = "(untitled)"
+		}
+		fmt.Fprintf(bw, heading, i+1, title)
+		note := strings.TrimSpace(s.Note)
+		if note == "" {
+			note = empty
+		}
+		fmt.Fprintf(bw, "%s\n\n", note)
+	}
+	return bw.Flush()
+}
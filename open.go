@@ -0,0 +1,103 @@
+package deckgen
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ReadDeck reads deck markup into a deck structure.
+func ReadDeck(r io.Reader) (*Deck, error) {
+	var d Deck
+	if err := xml.NewDecoder(r).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// deckfile is a temporary copy of a deck being appended to, which replaces
+// the deck at path when closed, if keep is set, and is removed otherwise.
+type deckfile struct {
+	*os.File
+	path string
+	keep bool
+}
+
+// Close closes the file, and replaces the deck with it or removes it.
+func (f *deckfile) Close() error {
+	err := f.File.Close()
+	if err == nil && f.keep {
+		return os.Rename(f.Name(), f.path)
+	}
+	os.Remove(f.Name())
+	return err
+}
+
+// OpenDeck opens an existing deck file for appending. The deck is parsed to
+// find its canvas size (1024x768 if it has none), and its slides are copied to a
+// temporary file in the same directory, so that slides made with the returned
+// generator are added after the existing ones. Finish with EndDeck and Close:
+// Close replaces the deck with the temporary file, and until then, or if the
+// deck is not ended or there is a write error, the deck is unchanged.
+func OpenDeck(path string) (*DeckGen, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d, err := ReadDeck(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	end := bytes.LastIndex(data, []byte(closedeck))
+	if end < 0 {
+		return nil, fmt.Errorf("%s: no %s", path, closedeck)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return nil, err
+	}
+	df := &deckfile{File: f, path: path}
+	if err := f.Chmod(info.Mode().Perm()); err != nil {
+		df.Close()
+		return nil, err
+	}
+	if _, err := f.Write(data[:end]); err != nil {
+		df.Close()
+		return nil, err
+	}
+	w, h := canvasof(d)
+	p := NewSlides(f, w, h)
+	p.closer = df
+	return p, nil
+}
+
+// Close flushes the output, closes the destination of a generator made by OpenDeck,
+// and reports the first write error, if any. Other destinations are not closed.
+// A deck opened by OpenDeck is only replaced if EndDeck was called and there were
+// no write errors.
+func (p *DeckGen) Close() error {
+	werr := p.Flush()
+	if p.closer == nil {
+		return werr
+	}
+	df, appending := p.closer.(*deckfile)
+	if appending {
+		df.keep = werr == nil && p.ended
+	}
+	err := p.closer.Close()
+	p.closer = nil
+	switch {
+	case werr != nil:
+		return werr
+	case err == nil && appending && !p.ended:
+		return fmt.Errorf("%s: deck not ended, left unchanged", df.path)
+	}
+	return err
+}
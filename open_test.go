@@ -0,0 +1,61 @@
+package deckgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenDeck(t *testing.T) {
+	tests := []struct {
+		name          string
+		deck          string
+		end           bool
+		slides        int
+		width, height int
+	}{
+		{"append", `<deck><canvas width="800" height="600"/><slide><text xp="10" yp="10">one</text></slide></deck>`, true, 2, 800, 600},
+		{"no canvas", `<deck><slide></slide></deck>`, true, 2, 1024, 768},
+		{"not ended", `<deck><canvas width="800" height="600"/><slide></slide></deck>`, false, 1, 800, 600},
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "deck.xml")
+		if err := os.WriteFile(path, []byte(test.deck), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		p, err := OpenDeck(path)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if p.width != test.width || p.height != test.height {
+			t.Errorf("%s: canvas %dx%d, want %dx%d", test.name, p.width, p.height, test.width, test.height)
+		}
+		p.StartSlide()
+		p.Rect(50, 50, 10, 10, "red")
+		p.EndSlide()
+		if test.end {
+			p.EndDeck()
+		}
+		if err := p.Close(); (err == nil) != test.end {
+			t.Errorf("%s: Close error %v", test.name, err)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := ReadDeck(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: ReadDeck: %v", test.name, err)
+		}
+		if len(d.Slide) != test.slides {
+			t.Errorf("%s: %d slides, want %d", test.name, len(d.Slide), test.slides)
+		}
+		if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+			t.Errorf("%s: %d files left, want 1", test.name, len(entries))
+		}
+	}
+	if _, err := OpenDeck(filepath.Join(t.TempDir(), "missing.xml")); err == nil {
+		t.Error("OpenDeck of a missing file succeeded")
+	}
+}
@@ -0,0 +1,250 @@
+package deckgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"sort"
+	"strings"
+)
+
+// jsonobj is a decoded JSON object.
+type jsonobj = map[string]interface{}
+
+// jsonstr returns the string value of key in obj, or "".
+func jsonstr(obj jsonobj, key string) string {
+	s, _ := obj[key].(string)
+	return s
+}
+
+// jsonmap returns the object value of key in obj, or nil.
+func jsonmap(obj jsonobj, key string) jsonobj {
+	m, _ := obj[key].(map[string]interface{})
+	return m
+}
+
+// sortedkeys returns the keys of obj in order.
+func sortedkeys(obj jsonobj) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schematype describes the type of a schema, and the names of any schemas it references.
+func schematype(s jsonobj) (string, []string) {
+	if ref := jsonstr(s, "$ref"); ref != "" {
+		name := path.Base(ref)
+		return name, []string{name}
+	}
+	t := jsonstr(s, "type")
+	switch t {
+	case "array":
+		if items := jsonmap(s, "items"); items != nil {
+			it, refs := schematype(items)
+			return "[]" + it, refs
+		}
+		return "[]", nil
+	case "":
+		for _, k := range []string{"allOf", "oneOf", "anyOf"} {
+			if list, ok := s[k].([]interface{}); ok {
+				var names, refs []string
+				for _, item := range list {
+					if m, ok := item.(map[string]interface{}); ok {
+						n, r := schematype(m)
+						names = append(names, n)
+						refs = append(refs, r...)
+					}
+				}
+				return strings.Join(names, "|"), refs
+			}
+		}
+		return "object", nil
+	}
+	if f := jsonstr(s, "format"); f != "" {
+		t += "(" + f + ")"
+	}
+	return t, nil
+}
+
+// schemadiagram draws each schema as a box listing its properties, with lines
+// connecting schemas that reference each other. Schemas are arranged in a grid,
+// continuing on additional slides as needed.
+func (p *DeckGen) schemadiagram(title string, schemas jsonobj) {
+	names := sortedkeys(schemas)
+	t := p.themeof(nil)
+	const perslide = 9
+	for start := 0; start < len(names); start += perslide {
+		end := start + perslide
+		if end > len(names) {
+			end = len(names)
+		}
+		page := names[start:end]
+		heading := title
+		if start > 0 {
+			heading += " (continued)"
+		}
+		p.reportslide(heading)
+		cols := int(math.Ceil(math.Sqrt(float64(len(page)))))
+		rows := (len(page) + cols - 1) / cols
+		area := Box{X: 5, Y: 5, W: 90, H: 78}
+		cw, rh := area.W/float64(cols), area.H/float64(rows)
+		boxes := map[string]Box{}
+		refs := map[string][]string{}
+		type entry struct {
+			name   string
+			fields []string
+		}
+		entries := make([]entry, len(page))
+		for i, name := range page {
+			s, _ := schemas[name].(map[string]interface{})
+			props := jsonmap(s, "properties")
+			required := map[string]bool{}
+			if req, ok := s["required"].([]interface{}); ok {
+				for _, r := range req {
+					if rs, ok := r.(string); ok {
+						required[rs] = true
+					}
+				}
+			}
+			var fields []string
+			for _, pn := range sortedkeys(props) {
+				pt, r := schematype(jsonmap(props, pn))
+				refs[name] = append(refs[name], r...)
+				mark := ""
+				if required[pn] {
+					mark = "*"
+				}
+				fields = append(fields, pn+mark+": "+pt)
+			}
+			if len(props) == 0 {
+				st, r := schematype(s)
+				refs[name] = append(refs[name], r...)
+				fields = append(fields, st)
+			}
+			c, r := i%cols, i/cols
+			bw := cw * 0.8
+			maxfields := int((rh*0.8 - p.hpct(2.4)) / p.hpct(1.6))
+			if maxfields < 1 {
+				maxfields = 1
+			}
+			if len(fields) > maxfields {
+				fields = append(fields[:maxfields-1], fmt.Sprintf("… %d more", len(fields)-maxfields+1))
+			}
+			bh := p.hpct(2.4) + float64(len(fields))*p.hpct(1.6) + p.hpct(0.6)
+			boxes[name] = Box{X: area.X + cw*float64(c) + (cw-bw)/2, Y: area.Top() - rh*float64(r) - rh*0.1 - bh, W: bw, H: bh}
+			entries[i] = entry{name, fields}
+		}
+		for _, e := range entries {
+			from := boxes[e.name]
+			fx, fy := from.Center()
+			for _, r := range refs[e.name] {
+				if to, ok := boxes[r]; ok && r != e.name {
+					tx, ty := to.Center()
+					p.Line(fx, fy, tx, ty, 0.15, t.Accent, 50)
+				}
+			}
+		}
+		for _, e := range entries {
+			b := boxes[e.name]
+			cx, cy := b.Center()
+			p.Rect(cx, cy, b.W, b.H, t.Bg)
+			p.Rect(cx, b.Top()-p.hpct(1.2), b.W, p.hpct(2.4), t.Accent)
+			p.TextMid(cx, b.Top()-p.hpct(1.6), e.name, t.Font, 1.2, "white")
+			for j, f := range e.fields {
+				p.Text(b.X+0.5, b.Top()-p.hpct(2.4)-p.hpct(1.6)*float64(j+1)+p.hpct(0.4), f, t.Mono, 0.9, t.Fg)
+			}
+		}
+		p.EndSlide()
+	}
+}
+
+// OpenAPISlides reads an OpenAPI (or Swagger) specification in JSON, and generates
+// a title slide, an endpoint summary table, and diagrams of the schema objects.
+func (p *DeckGen) OpenAPISlides(r io.Reader) error {
+	var spec jsonobj
+	if err := json.NewDecoder(r).Decode(&spec); err != nil {
+		return err
+	}
+	info := jsonmap(spec, "info")
+	title := jsonstr(info, "title")
+	if title == "" {
+		title = "API"
+	}
+	t := p.themeof(nil)
+	p.reportslide("")
+	p.TextMid(50, 55, title, t.TitleFont, 5, t.Fg)
+	sub := jsonstr(info, "version")
+	if v := jsonstr(spec, "openapi"); v != "" {
+		sub += "  ·  OpenAPI " + v
+	} else if v := jsonstr(spec, "swagger"); v != "" {
+		sub += "  ·  Swagger " + v
+	}
+	p.TextMid(50, 45, sub, t.Font, 2.5, t.Muted)
+	if d := jsonstr(info, "description"); d != "" {
+		p.TextBlock(20, 35, d, t.Font, 1.5, 60, t.Fg)
+	}
+	p.EndSlide()
+
+	methods := []string{"get", "post", "put", "patch", "delete", "head", "options"}
+	paths := jsonmap(spec, "paths")
+	var rows [][]string
+	for _, pth := range sortedkeys(paths) {
+		item := jsonmap(paths, pth)
+		for _, m := range methods {
+			op := jsonmap(item, m)
+			if op == nil {
+				continue
+			}
+			summary := jsonstr(op, "summary")
+			if summary == "" {
+				summary = jsonstr(op, "operationId")
+			}
+			rows = append(rows, []string{strings.ToUpper(m), pth, summary})
+		}
+	}
+	if len(rows) > 0 {
+		p.reportslide(fmt.Sprintf("Endpoints (%d)", len(rows)))
+		p.Table(5, 82, 90, []string{"Method", "Path", "Summary"}, rows, TableStyle{Size: 1.2})
+		p.EndSlide()
+	}
+
+	schemas := jsonmap(jsonmap(spec, "components"), "schemas")
+	if schemas == nil {
+		schemas = jsonmap(spec, "definitions")
+	}
+	if len(schemas) > 0 {
+		p.schemadiagram("Schemas", schemas)
+	}
+	return nil
+}
+
+// SchemaSlides reads a JSON Schema and generates diagrams of the root schema and its definitions.
+func (p *DeckGen) SchemaSlides(r io.Reader) error {
+	var schema jsonobj
+	if err := json.NewDecoder(r).Decode(&schema); err != nil {
+		return err
+	}
+	all := jsonobj{}
+	for _, k := range []string{"$defs", "definitions"} {
+		for name, s := range jsonmap(schema, k) {
+			all[name] = s
+		}
+	}
+	root := jsonstr(schema, "title")
+	if root == "" {
+		root = "root"
+	}
+	if jsonmap(schema, "properties") != nil {
+		all[root] = schema
+	}
+	if len(all) == 0 {
+		return fmt.Errorf("schema has no properties or definitions")
+	}
+	p.schemadiagram(root, all)
+	return nil
+}
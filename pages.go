@@ -0,0 +1,162 @@
+package deckgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PageNumberOptions are optional settings of EnablePageNumbers.
+type PageNumberOptions struct {
+	SkipFirst bool    // leave the first (title) slide unnumbered
+	Font      string  // text font (default: the deck font)
+	Size      float64 // text size (default: 1.2)
+	Color     string  // text color (default: the theme's muted color)
+}
+
+// pagenumbers is the page numbering of a deck.
+type pagenumbers struct {
+	position string
+	format   string
+	PageNumberOptions
+}
+
+// progress is the progress indicator of a deck.
+type progress struct {
+	style, color string
+}
+
+// slidemarker marks, in deferred output, where the elements that depend on the
+// number of slides go, with the slide number.
+var slidemarker = regexp.MustCompile("\x00[0-9]+\x00")
+
+// deferslides holds the output of the following slides until EndDeck, when the
+// number of slides is known.
+func (p *DeckGen) deferslides() {
+	if p.deferred != nil {
+		return
+	}
+	p.deferdest = p.dest
+	p.deferred = new(bytes.Buffer)
+	p.dest = p.deferred
+}
+
+// slidebegun counts a new slide, and records where it begins in deferred output.
+func (p *DeckGen) slidebegun() {
+	p.nslides++
+	if p.deferred != nil && p.dest == io.Writer(p.deferred) {
+		if p.slideat == nil {
+			p.slideat = map[int]int{}
+		}
+		p.slideat[p.nslides] = p.deferred.Len()
+	}
+}
+
+// assemble writes the deferred output, completing each slide with the elements
+// that depend on the number of slides.
+func (p *DeckGen) assemble() {
+	if p.deferred == nil {
+		return
+	}
+	markup := p.deferred.Bytes()
+	if p.toc != nil {
+		markup = p.inserttoc(markup)
+	}
+	p.dest, p.deferred = p.deferdest, nil
+	total := p.nslides
+	p.dest.Write(slidemarker.ReplaceAllFunc(markup, func(m []byte) []byte {
+		n, _ := strconv.Atoi(string(m[1 : len(m)-1]))
+		return []byte(p.Capture(func(p *DeckGen) { p.numbered(n, total) }))
+	}))
+}
+
+// EnablePageNumbers places the slide number on each following slide. The position is one of
+// "bottom-right" (the default), "bottom-left", "bottom-center", "top-right", "top-left" or
+// "top-center". In the format, {n} is replaced by the slide number and {total} by the number
+// of slides; the default is "{n} / {total}". Since the number of slides is not known until
+// EndDeck, the output of the following slides is held until then.
+func (p *DeckGen) EnablePageNumbers(position, format string, opts ...PageNumberOptions) {
+	pn := &pagenumbers{position: position, format: format}
+	if len(opts) > 0 {
+		pn.PageNumberOptions = opts[0]
+	}
+	if pn.format == "" {
+		pn.format = "{n} / {total}"
+	}
+	if pn.Size == 0 {
+		pn.Size = 1.2
+	}
+	if pn.Color == "" {
+		pn.Color = p.themeof(nil).Muted
+	}
+	p.pagenums = pn
+	p.deferslides()
+}
+
+// EnableProgress draws an indicator of the position of each following slide within the deck,
+// in the given color (default: the theme's accent), at the bottom edge of the slide. The style is "bar"
+// (the default), a thin bar whose length grows from slide to slide, or "dots", a strip of dots
+// with the current slide's dot solid. Like page numbers, this holds the output of the following
+// slides until EndDeck.
+func (p *DeckGen) EnableProgress(style, color string) {
+	if color == "" {
+		color = p.themeof(nil).Accent
+	}
+	p.progress = &progress{style: style, color: color}
+	p.deferslides()
+}
+
+// draw places the progress indicator for slide n of total.
+func (pr *progress) draw(p *DeckGen, n, total int) {
+	if total == 0 {
+		return
+	}
+	if pr.style == "dots" {
+		step := math.Min(2, 80/float64(total))
+		x := 50 - step*float64(total-1)/2
+		for i := 1; i <= total; i++ {
+			if i == n {
+				p.Circle(x, 1.5, step*0.5, pr.color)
+			} else {
+				p.Circle(x, 1.5, step*0.5, pr.color, 30)
+			}
+			x += step
+		}
+		return
+	}
+	w := 100 * float64(n) / float64(total)
+	p.Rect(w/2, 0.4, w, 0.8, pr.color)
+}
+
+// slidemark marks the current slide for completion when the deck is assembled.
+func (p *DeckGen) slidemark() {
+	if p.deferred != nil {
+		fmt.Fprintf(p.out(), "\x00%d\x00", p.nslides)
+	}
+}
+
+// numbered places the elements of slide n, of total, that depend on the number of slides.
+func (p *DeckGen) numbered(n, total int) {
+	if p.progress != nil {
+		p.progress.draw(p, n, total)
+	}
+	if pn := p.pagenums; pn != nil && !(pn.SkipFirst && n == 1) {
+		s := strings.NewReplacer("{n}", strconv.Itoa(n), "{total}", strconv.Itoa(total)).Replace(pn.format)
+		y := 2.5
+		if strings.HasPrefix(pn.position, "top") {
+			y = 96
+		}
+		switch {
+		case strings.HasSuffix(pn.position, "left"):
+			p.textaligned(5, y, s, pn.Font, pn.Size, pn.Color, "", 100)
+		case strings.HasSuffix(pn.position, "center"):
+			p.textaligned(50, y, s, pn.Font, pn.Size, pn.Color, "center", 100)
+		default:
+			p.textaligned(95, y, s, pn.Font, pn.Size, pn.Color, "end", 100)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package deckgen
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// slidenumbers returns the page number text, if any, of each slide of a deck.
+func slidenumbers(deck string) []string {
+	re := regexp.MustCompile(`<text xp="(?:5|50|95)\.00" yp="(?:2\.50|96\.00)"[^>]*>([^<]*)</text>`)
+	var nums []string
+	for _, s := range strings.Split(deck, "<slide")[1:] {
+		n := ""
+		if m := re.FindAllStringSubmatch(s, -1); len(m) > 0 {
+			n = m[len(m)-1][1]
+		}
+		nums = append(nums, n)
+	}
+	return nums
+}
+
+func TestPageNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		format   string
+		opts     PageNumberOptions
+		want     []string
+		at       string // position attributes of the numbers
+	}{
+		{name: "default", want: []string{"1 / 3", "2 / 3", "3 / 3"}, at: `xp="95.00" yp="2.50"`},
+		{name: "skip first", opts: PageNumberOptions{SkipFirst: true}, want: []string{"", "2 / 3", "3 / 3"}},
+		{name: "format", format: "page {n} of {total}", want: []string{"page 1 of 3", "page 2 of 3", "page 3 of 3"}},
+		{name: "top left", position: "top-left", want: []string{"1 / 3", "2 / 3", "3 / 3"}, at: `xp="5.00" yp="96.00"`},
+		{name: "bottom center", position: "bottom-center", want: []string{"1 / 3", "2 / 3", "3 / 3"}, at: `xp="50.00" yp="2.50"`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.EnablePageNumbers(test.position, test.format, test.opts)
+		p.StartDeck()
+		for i := 0; i < 3; i++ {
+			p.StartSlide()
+			p.Text(10, 50, "content", "sans", 3, "")
+			p.EndSlide()
+		}
+		p.EndDeck()
+		out := buf.String()
+		if got := slidenumbers(out); strings.Join(got, "|") != strings.Join(test.want, "|") {
+			t.Errorf("%s: page numbers %q, want %q", test.name, got, test.want)
+		}
+		if test.at != "" && !strings.Contains(out, `<text `+test.at) {
+			t.Errorf("%s: no page number at %s", test.name, test.at)
+		}
+		if strings.Contains(out, "\x00") {
+			t.Errorf("%s: slide markers left in output", test.name)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	tests := []struct {
+		style string
+		want  []string
+	}{
+		{"bar", []string{`<rect xp="16.67" yp="0.40" wp="33.33"`, `<rect xp="33.33" yp="0.40" wp="66.67"`, `<rect xp="50.00" yp="0.40" wp="100.00"`}},
+		{"dots", []string{`<ellipse `, `<ellipse `, `<ellipse `}},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.EnableProgress(test.style, "")
+		p.StartDeck()
+		for i := 0; i < 3; i++ {
+			p.StartSlide()
+			p.EndSlide()
+		}
+		p.EndDeck()
+		slides := strings.Split(buf.String(), "<slide")[1:]
+		if len(slides) != len(test.want) {
+			t.Fatalf("%s: %d slides, want %d", test.style, len(slides), len(test.want))
+		}
+		for i, w := range test.want {
+			if !strings.Contains(slides[i], w) {
+				t.Errorf("%s: slide %d lacks %q", test.style, i+1, w)
+			}
+		}
+		if test.style == "dots" {
+			for i, s := range slides {
+				if n := strings.Count(s, "<ellipse "); n != 3 {
+					t.Errorf("dots: slide %d has %d dots, want 3", i+1, n)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package deckgen
+
+import "strings"
+
+// continued marks the titles of continuation slides, whose content begins at continuedy.
+const (
+	continued  = " (continued)"
+	continuedy = 80
+)
+
+// SetAutoPaginate turns on the continuation of lists and text blocks that would extend below
+// bottom (a y coordinate) onto new slides, with the same colors and context, titled as the
+// slide they continue with "(continued)", below the title. Their extent is computed from
+// font metrics.
+// A bottom of 0 (the default) turns this off.
+func (p *DeckGen) SetAutoPaginate(bottom float64) {
+	p.paginate = bottom
+}
+
+// paginating reports whether an element at rotation may be continued on a new slide.
+func (p *DeckGen) paginating(rotation float64) bool {
+	return p.paginate > 0 && p.inslide && rotation == 0 && len(p.xforms) == 0
+}
+
+// continueslide ends the current slide, and begins a continuation of it.
+func (p *DeckGen) continueslide() {
+	c := p.ctx
+	if c.Bg == "" && len(p.slidecolors) > 0 {
+		c.Bg = p.slidecolors[0]
+	}
+	if c.Fg == "" && len(p.slidecolors) > 1 {
+		c.Fg = p.slidecolors[1]
+	}
+	c.Title = strings.TrimSuffix(c.Title, continued) + continued
+	if c.Title == continued {
+		c.Title = strings.TrimSpace(continued)
+	}
+	p.EndSlide()
+	p.StartSlideContext(c)
+}
+
+// listfit returns the number of items of a list that fit above the pagination bottom,
+// at least one.
+func (p *DeckGen) listfit(l List, items []string) int {
+	if !p.paginating(l.Rotation) {
+		return len(items)
+	}
+	size := p.sizeof(l.Sp)
+	font := p.fontof(l.Font)
+	step := p.hpct(size * leading(l.Lp))
+	lines := 0
+	for i, s := range items {
+		n := 1
+		if l.Wp > 0 {
+			n = len(wraplines(s, font, size, l.Wp))
+		}
+		if i > 0 && l.Yp-step*float64(lines+n-1) < p.paginate {
+			return i
+		}
+		lines += n
+	}
+	return len(items)
+}
+
+// blocksplit splits the text of a block into the lines that fit above the pagination bottom,
+// at least one, and the rest, reporting whether there is any rest. Paragraph breaks are kept.
+func (p *DeckGen) blocksplit(t Text) (head, rest string, split bool) {
+	if t.Type != "block" || t.Wp <= 0 || !p.paginating(t.Rotation) {
+		return "", "", false
+	}
+	size := p.sizeof(t.Sp)
+	font := p.fontof(t.Font)
+	n := int((t.Yp-p.paginate)/p.hpct(size*leading(t.Lp))) + 1
+	if n < 1 {
+		n = 1
+	}
+	var heads, rests []string
+	k := 0
+	for _, para := range strings.Split(t.Tdata, "\n") {
+		lines := wraplines(para, font, size, t.Wp)
+		switch {
+		case k+len(lines) <= n:
+			heads = append(heads, para)
+		case k >= n:
+			rests = append(rests, para)
+		default:
+			heads = append(heads, strings.Join(lines[:n-k], " "))
+			rests = append(rests, strings.Join(lines[n-k:], " "))
+		}
+		k += len(lines)
+	}
+	return strings.Join(heads, "\n"), strings.Join(rests, "\n"), k > n
+}
@@ -0,0 +1,68 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	long := strings.Repeat("word ", 40)
+	tests := []struct {
+		name   string
+		make   func(p *DeckGen)
+		slides int
+		want   []string
+	}{
+		{
+			name: "block keeps paragraph breaks",
+			make: func(p *DeckGen) {
+				p.TextBlock(10, 30, "one\ntwo\nthree\nfour\nfive\nsix", "sans", 3, 80, "black")
+			},
+			slides: 2,
+			want:   []string{"one\ntwo", "five\nsix"},
+		},
+		{
+			name: "list items",
+			make: func(p *DeckGen) {
+				var items []ListItem
+				for i := 0; i < 12; i++ {
+					items = append(items, ListItem{ListText: "item"})
+				}
+				p.ListItems(10, 30, 3, 1.5, 0, items, "bullet")
+			},
+			slides: 2,
+		},
+		{
+			name: "nested list",
+			make: func(p *DeckGen) {
+				var items []NestedItem
+				for i := 0; i < 12; i++ {
+					items = append(items, NestedItem{Text: long, Level: i % 2})
+				}
+				p.ListNested(10, 30, 3, 1.5, 80, items, "sans", "black")
+			},
+			slides: 4,
+			want:   []string{"– word"},
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.SetAutoPaginate(10)
+		p.StartDeck()
+		p.StartSlide()
+		test.make(p)
+		p.EndSlide()
+		p.EndDeck()
+		out := buf.String()
+		if n := strings.Count(out, "<slide"); n < test.slides {
+			t.Errorf("%s: %d slides, want at least %d", test.name, n, test.slides)
+		}
+		for _, w := range test.want {
+			if !strings.Contains(out, w) {
+				t.Errorf("%s: output has no %q", test.name, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package deckgen
+
+import (
+	"math"
+	"sort"
+)
+
+// Pattern describes a fill made of lines or dots, which distinguishes areas without color.
+type Pattern struct {
+	Style   string  // hatch, crosshatch, stripes, or dots (default: hatch)
+	Color   string  // color of the lines or dots (default: the theme's foreground)
+	Spacing float64 // distance between lines or dots, in canvas width percentages (default: 1.5)
+	Size    float64 // line thickness or dot diameter (default: 0.15, or 0.4 for dots)
+	Angle   float64 // angle of lines, in degrees (default: 45 for hatch, 0 for stripes)
+	Outline bool    // draw the outline of the shape
+}
+
+// patterndefaults fills in the unset fields of a pattern.
+func (p *DeckGen) patterndefaults(pat Pattern) Pattern {
+	if pat.Style == "" {
+		pat.Style = "hatch"
+	}
+	if pat.Color == "" {
+		pat.Color = p.themeof(nil).Fg
+	}
+	if pat.Spacing <= 0 {
+		pat.Spacing = 1.5
+	}
+	if pat.Size <= 0 {
+		pat.Size = 0.15
+		if pat.Style == "dots" {
+			pat.Size = 0.4
+		}
+	}
+	if pat.Angle == 0 && pat.Style == "hatch" {
+		pat.Angle = 45
+	}
+	return pat
+}
+
+// hatchlines returns the segments of parallel lines at angle degrees, spacing apart, within
+// the polygon pts. Coordinates are in canvas width percentages on both axes.
+func hatchlines(pts []Point, angle, spacing float64) [][2]Point {
+	s, c := math.Sincos(-angle * math.Pi / 180)
+	rot := make([]Point, len(pts))
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, pt := range pts {
+		rot[i] = Point{X: pt.X*c - pt.Y*s, Y: pt.X*s + pt.Y*c}
+		lo = math.Min(lo, rot[i].Y)
+		hi = math.Max(hi, rot[i].Y)
+	}
+	back := func(x, y float64) Point {
+		return Point{X: x*c + y*s, Y: -x*s + y*c}
+	}
+	var segs [][2]Point
+	for y := math.Floor(lo/spacing)*spacing + spacing/2; y < hi; y += spacing {
+		var xs []float64
+		for i, a := range rot {
+			b := rot[(i+1)%len(rot)]
+			if (a.Y <= y) != (b.Y <= y) {
+				xs = append(xs, a.X+(y-a.Y)/(b.Y-a.Y)*(b.X-a.X))
+			}
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			segs = append(segs, [2]Point{back(xs[i], y), back(xs[i+1], y)})
+		}
+	}
+	return segs
+}
+
+// PatternPolygon fills the polygon with coordinates in x and y slices with a pattern.
+func (p *DeckGen) PatternPolygon(x, y []float64, pat Pattern) {
+	if len(x) < 3 || len(x) != len(y) {
+		return
+	}
+	pat = p.patterndefaults(pat)
+	// work in width units on both axes, so that angles and spacing are true
+	pts := make([]Point, len(x))
+	for i := range x {
+		pts[i] = Point{X: x[i], Y: p.wpct(y[i])}
+	}
+	var angles []float64
+	switch pat.Style {
+	case "dots":
+		p.patterndots(pts, pat)
+	case "crosshatch":
+		angles = []float64{pat.Angle + 45, pat.Angle - 45}
+	default:
+		angles = []float64{pat.Angle}
+	}
+	for _, a := range angles {
+		for _, s := range hatchlines(pts, a, pat.Spacing) {
+			p.Line(s[0].X, p.hpct(s[0].Y), s[1].X, p.hpct(s[1].Y), pat.Size, pat.Color)
+		}
+	}
+	if pat.Outline {
+		n := len(x)
+		p.Polyline(append(x[:n:n], x[0]), append(y[:n:n], y[0]), pat.Size, pat.Color, 100)
+	}
+}
+
+// patterndots fills the polygon pts, in width units, with a staggered grid of dots.
+func (p *DeckGen) patterndots(pts []Point, pat Pattern) {
+	b := boxpoints(Coords(pts))
+	row := 0
+	for y := b.Y + pat.Spacing/2; y < b.Top(); y += pat.Spacing {
+		off := 0.0
+		if row%2 == 1 {
+			off = pat.Spacing / 2
+		}
+		for x := b.X + pat.Spacing/4 + off; x < b.Right(); x += pat.Spacing {
+			if inpolygon(Point{X: x, Y: y}, pts) {
+				p.Circle(x, p.hpct(y), pat.Size, pat.Color)
+			}
+		}
+		row++
+	}
+}
+
+// PatternRect fills a rectangle, centered at (x,y), with (w,h) dimensions, with a pattern.
+func (p *DeckGen) PatternRect(x, y, w, h float64, pat Pattern) {
+	b := centerbox(x, y, w, h)
+	p.PatternPolygon([]float64{b.X, b.Right(), b.Right(), b.X}, []float64{b.Y, b.Y, b.Top(), b.Top()}, pat)
+}
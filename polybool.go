@@ -0,0 +1,394 @@
+package deckgen
+
+import (
+	"math"
+	"sort"
+)
+
+// Point is a location in canvas percentages.
+type Point struct {
+	X, Y float64
+}
+
+// Points converts slices of x and y coordinates to points.
+func Points(x, y []float64) []Point {
+	n := len(x)
+	if len(y) < n {
+		n = len(y)
+	}
+	pts := make([]Point, n)
+	for i := 0; i < n; i++ {
+		pts[i] = Point{x[i], y[i]}
+	}
+	return pts
+}
+
+// Coords converts points to slices of x and y coordinates.
+func Coords(pts []Point) ([]float64, []float64) {
+	x := make([]float64, len(pts))
+	y := make([]float64, len(pts))
+	for i, pt := range pts {
+		x[i], y[i] = pt.X, pt.Y
+	}
+	return x, y
+}
+
+// PolygonPoints makes a polygon from a set of points, with the specified color and optional opacity.
+func (p *DeckGen) PolygonPoints(pts []Point, color string, opacity ...float64) {
+	x, y := Coords(pts)
+	p.Polygon(x, y, color, opacity...)
+}
+
+// PolygonSet makes a polygon for each point set, with the specified color and optional opacity.
+func (p *DeckGen) PolygonSet(polys [][]Point, color string, opacity ...float64) {
+	for _, pts := range polys {
+		p.PolygonPoints(pts, color, opacity...)
+	}
+}
+
+// polyarea returns the signed area of a polygon; positive when counter-clockwise.
+func polyarea(pts []Point) float64 {
+	a := 0.0
+	for i := range pts {
+		j := (i + 1) % len(pts)
+		a += pts[i].X*pts[j].Y - pts[j].X*pts[i].Y
+	}
+	return a / 2
+}
+
+// inpolygon reports whether pt is inside the polygon, using the even-odd rule.
+func inpolygon(pt Point, pts []Point) bool {
+	in := false
+	for i, j := 0, len(pts)-1; i < len(pts); j, i = i, i+1 {
+		a, b := pts[i], pts[j]
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < (b.X-a.X)*(pt.Y-a.Y)/(b.Y-a.Y)+a.X {
+			in = !in
+		}
+	}
+	return in
+}
+
+// polyvertex is a node in the circular vertex lists used for clipping.
+type polyvertex struct {
+	pt         Point
+	next, prev *polyvertex
+	intersect  bool
+	entry      bool
+	visited    bool
+	alpha      float64
+	neighbor   *polyvertex
+}
+
+// polylist builds a circular list of vertices, returning the first.
+func polylist(pts []Point) *polyvertex {
+	var first, last *polyvertex
+	for _, pt := range pts {
+		v := &polyvertex{pt: pt}
+		if first == nil {
+			first = v
+		} else {
+			last.next, v.prev = v, last
+		}
+		last = v
+	}
+	last.next, first.prev = first, last
+	return first
+}
+
+// nextoriginal returns the next vertex that is not an intersection.
+func nextoriginal(v *polyvertex) *polyvertex {
+	for v.intersect {
+		v = v.next
+	}
+	return v
+}
+
+// insertvertex places an intersection between start and end, ordered by alpha.
+func insertvertex(v, start, end *polyvertex) {
+	c := start.next
+	for c != end && c.alpha < v.alpha {
+		c = c.next
+	}
+	v.next, v.prev = c, c.prev
+	c.prev.next = v
+	c.prev = v
+}
+
+// segintersect returns the intersection of segments a1-a2 and b1-b2,
+// with the fractional positions along each, if they properly cross.
+func segintersect(a1, a2, b1, b2 Point) (Point, float64, float64, bool) {
+	d := (b2.Y-b1.Y)*(a2.X-a1.X) - (b2.X-b1.X)*(a2.Y-a1.Y)
+	if d == 0 {
+		return Point{}, 0, 0, false
+	}
+	ua := ((b2.X-b1.X)*(a1.Y-b1.Y) - (b2.Y-b1.Y)*(a1.X-b1.X)) / d
+	ub := ((a2.X-a1.X)*(a1.Y-b1.Y) - (a2.Y-a1.Y)*(a1.X-b1.X)) / d
+	if ua <= 0 || ua >= 1 || ub <= 0 || ub >= 1 {
+		return Point{}, 0, 0, false
+	}
+	return Point{a1.X + ua*(a2.X-a1.X), a1.Y + ua*(a2.Y-a1.Y)}, ua, ub, true
+}
+
+// onsegment reports whether pt lies on the segment a-b, within a small tolerance.
+func onsegment(pt, a, b Point) bool {
+	const eps = 1e-9
+	cross := (b.X-a.X)*(pt.Y-a.Y) - (b.Y-a.Y)*(pt.X-a.X)
+	if math.Abs(cross) > eps*math.Hypot(b.X-a.X, b.Y-a.Y) {
+		return false
+	}
+	return pt.X >= math.Min(a.X, b.X)-eps && pt.X <= math.Max(a.X, b.X)+eps &&
+		pt.Y >= math.Min(a.Y, b.Y)-eps && pt.Y <= math.Max(a.Y, b.Y)+eps
+}
+
+// perturb returns b with the vertices of a that lie on its edges added, and every vertex
+// lying on an edge or vertex of a nudged out of (grow) or into b, removing the shared
+// edges and vertices that the clipping algorithm cannot handle. Growing b makes touching
+// polygons overlap, and shrinking it makes them separate.
+func perturb(a, b []Point, grow bool) []Point {
+	const nudge = 1e-6
+	var pts []Point
+	for i := range b {
+		c, d := b[i], b[(i+1)%len(b)]
+		pts = append(pts, c)
+		var on []Point
+		for _, pt := range a {
+			if pt != c && pt != d && onsegment(pt, c, d) {
+				on = append(on, pt)
+			}
+		}
+		sort.Slice(on, func(i, j int) bool {
+			return math.Hypot(on[i].X-c.X, on[i].Y-c.Y) < math.Hypot(on[j].X-c.X, on[j].Y-c.Y)
+		})
+		pts = append(pts, on...)
+	}
+	dir := nudge
+	if (polyarea(pts) > 0) != grow {
+		dir = -nudge
+	}
+	out := make([]Point, len(pts))
+	for i, pt := range pts {
+		out[i] = pt
+		touching := false
+		for j := range a {
+			if onsegment(pt, a[j], a[(j+1)%len(a)]) {
+				touching = true
+				break
+			}
+		}
+		if !touching {
+			continue
+		}
+		prev, next := pts[(i+len(pts)-1)%len(pts)], pts[(i+1)%len(pts)]
+		nx, ny := edgenormal(prev, pt)
+		mx, my := edgenormal(pt, next)
+		nx, ny = nx+mx, ny+my
+		if l := math.Hypot(nx, ny); l > 0 {
+			nx, ny = nx/l, ny/l
+		} else {
+			nx, ny = math.Sqrt2/2, math.Sqrt2/2
+		}
+		out[i] = Point{pt.X + dir*nx, pt.Y + dir*ny}
+	}
+	return out
+}
+
+// edgenormal returns the unit normal to the right of the edge from a to b,
+// which points out of a counter-clockwise polygon.
+func edgenormal(a, b Point) (float64, float64) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return 0, 0
+	}
+	return dy / l, -dx / l
+}
+
+// keyhole joins a hole to its enclosing polygon with a zero-width bridge,
+// so that a polygon with a hole can be drawn as a single polygon.
+func keyhole(outer, hole []Point) []Point {
+	oi, hi, best := 0, 0, math.Inf(1)
+	for i, a := range outer {
+		for j, b := range hole {
+			if d := math.Hypot(a.X-b.X, a.Y-b.Y); d < best {
+				oi, hi, best = i, j, d
+			}
+		}
+	}
+	h := make([]Point, len(hole))
+	copy(h, hole)
+	if (polyarea(outer) > 0) == (polyarea(hole) > 0) {
+		for i, j := 0, len(h)-1; i < j; i, j = i+1, j-1 {
+			h[i], h[j] = h[j], h[i]
+		}
+		hi = len(h) - 1 - hi
+	}
+	pts := make([]Point, 0, len(outer)+len(hole)+2)
+	pts = append(pts, outer[:oi+1]...)
+	for k := 0; k <= len(h); k++ {
+		pts = append(pts, h[(hi+k)%len(h)])
+	}
+	pts = append(pts, outer[oi:]...)
+	return pts
+}
+
+// clip performs a Greiner-Hormann boolean operation on two simple polygons.
+// The subject and clip flags select which side of each polygon is kept:
+// intersection keeps both insides, union both outsides, and difference
+// the subject outside and clip inside.
+func clip(a, b []Point, subjectfwd, clipfwd bool) ([][]Point, bool) {
+	sa, sb := polylist(a), polylist(b)
+
+	found := false
+	for s := sa; ; {
+		sn := nextoriginal(s.next)
+		for c := sb; ; {
+			cn := nextoriginal(c.next)
+			if pt, ua, ub, ok := segintersect(s.pt, sn.pt, c.pt, cn.pt); ok {
+				vs := &polyvertex{pt: pt, intersect: true, alpha: ua}
+				vc := &polyvertex{pt: pt, intersect: true, alpha: ub}
+				vs.neighbor, vc.neighbor = vc, vs
+				insertvertex(vs, s, sn)
+				insertvertex(vc, c, cn)
+				found = true
+			}
+			if c = cn; c == sb {
+				break
+			}
+		}
+		if s = sn; s == sa {
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	mark := func(first *polyvertex, other []Point, forward bool) {
+		status := forward != inpolygon(first.pt, other)
+		for v := first; ; {
+			if v.intersect {
+				v.entry = status
+				status = !status
+			}
+			if v = v.next; v == first {
+				break
+			}
+		}
+	}
+	mark(sa, b, subjectfwd)
+	mark(sb, a, clipfwd)
+
+	var result [][]Point
+	for {
+		var start *polyvertex
+		for v := sa; ; {
+			if v.intersect && !v.visited {
+				start = v
+				break
+			}
+			if v = v.next; v == sa {
+				break
+			}
+		}
+		if start == nil {
+			break
+		}
+		cur := start
+		pts := []Point{cur.pt}
+		for {
+			cur.visited, cur.neighbor.visited = true, true
+			if cur.entry {
+				for cur = cur.next; ; cur = cur.next {
+					pts = append(pts, cur.pt)
+					if cur.intersect {
+						break
+					}
+				}
+			} else {
+				for cur = cur.prev; ; cur = cur.prev {
+					pts = append(pts, cur.pt)
+					if cur.intersect {
+						break
+					}
+				}
+			}
+			cur = cur.neighbor
+			if cur.visited {
+				break
+			}
+		}
+		if n := len(pts); n > 1 && pts[0] == pts[n-1] {
+			pts = pts[:n-1]
+		}
+		if len(pts) >= 3 {
+			result = append(result, pts)
+		}
+	}
+	return result, true
+}
+
+// Union returns the polygons covering the area of either a or b.
+// Polygons that touch along an edge are joined.
+func Union(a, b []Point) [][]Point {
+	switch {
+	case len(a) < 3 && len(b) < 3:
+		return nil
+	case len(a) < 3:
+		return [][]Point{b}
+	case len(b) < 3:
+		return [][]Point{a}
+	}
+	b = perturb(a, b, true)
+	if r, ok := clip(a, b, false, false); ok {
+		return r
+	}
+	switch {
+	case inpolygon(a[0], b):
+		return [][]Point{b}
+	case inpolygon(b[0], a):
+		return [][]Point{a}
+	}
+	return [][]Point{a, b}
+}
+
+// Intersect returns the polygons covering the area common to a and b.
+// Polygons that only touch along an edge have none.
+func Intersect(a, b []Point) [][]Point {
+	if len(a) < 3 || len(b) < 3 {
+		return nil
+	}
+	b = perturb(a, b, false)
+	if r, ok := clip(a, b, true, true); ok {
+		return r
+	}
+	switch {
+	case inpolygon(a[0], b):
+		return [][]Point{a}
+	case inpolygon(b[0], a):
+		return [][]Point{b}
+	}
+	return nil
+}
+
+// Subtract returns the polygons covering the area of a that is not in b.
+// When b lies entirely within a, the hole is joined to the outline so
+// that the result can be drawn as a single polygon, making rings and cutouts.
+func Subtract(a, b []Point) [][]Point {
+	if len(a) < 3 {
+		return nil
+	}
+	if len(b) < 3 {
+		return [][]Point{a}
+	}
+	b = perturb(a, b, true)
+	if r, ok := clip(a, b, false, true); ok {
+		return r
+	}
+	switch {
+	case inpolygon(a[0], b):
+		return nil
+	case inpolygon(b[0], a):
+		return [][]Point{keyhole(a, b)}
+	}
+	return [][]Point{a}
+}
@@ -0,0 +1,68 @@
+package deckgen
+
+import (
+	"math"
+	"testing"
+)
+
+// box returns the counter-clockwise rectangle from (x1,y1) to (x2,y2).
+func box(x1, y1, x2, y2 float64) []Point {
+	return []Point{{x1, y1}, {x2, y1}, {x2, y2}, {x1, y2}}
+}
+
+// totalarea returns the total unsigned area of a set of polygons.
+func totalarea(polys [][]Point) float64 {
+	a := 0.0
+	for _, pts := range polys {
+		a += math.Abs(polyarea(pts))
+	}
+	return a
+}
+
+func TestPolyBool(t *testing.T) {
+	line := []Point{{0, 0}, {1, 1}}
+	tests := []struct {
+		name string
+		op   func(a, b []Point) [][]Point
+		a, b []Point
+		n    int
+		area float64
+	}{
+		{"union overlap", Union, box(0, 0, 2, 2), box(1, 1, 3, 3), 1, 7},
+		{"intersect overlap", Intersect, box(0, 0, 2, 2), box(1, 1, 3, 3), 1, 1},
+		{"subtract overlap", Subtract, box(0, 0, 2, 2), box(1, 1, 3, 3), 1, 3},
+		{"union shared edge", Union, box(0, 0, 1, 1), box(1, 0, 2, 1), 1, 2},
+		{"intersect shared edge", Intersect, box(0, 0, 1, 1), box(1, 0, 2, 1), 0, 0},
+		{"subtract shared edge", Subtract, box(0, 0, 1, 1), box(1, 0, 2, 1), 1, 1},
+		{"union partial edge", Union, box(0, 0, 2, 1), box(1, 1, 2, 2), 1, 3},
+		{"intersect partial edge", Intersect, box(0, 0, 2, 1), box(1, 1, 2, 2), 0, 0},
+		{"subtract partial edge", Subtract, box(0, 0, 2, 1), box(1, 1, 2, 2), 1, 2},
+		{"union shared vertex", Union, box(0, 0, 1, 1), box(1, 1, 2, 2), 1, 2},
+		{"intersect shared vertex", Intersect, box(0, 0, 1, 1), box(1, 1, 2, 2), 0, 0},
+		{"union same", Union, box(0, 0, 1, 1), box(0, 0, 1, 1), 1, 1},
+		{"intersect same", Intersect, box(0, 0, 1, 1), box(0, 0, 1, 1), 1, 1},
+		{"subtract same", Subtract, box(0, 0, 1, 1), box(0, 0, 1, 1), 0, 0},
+		{"subtract same reversed", Subtract, box(0, 0, 1, 1), []Point{{0, 1}, {1, 1}, {1, 0}, {0, 0}}, 0, 0},
+		{"union inside", Union, box(0, 0, 4, 4), box(1, 1, 2, 2), 1, 16},
+		{"intersect inside", Intersect, box(0, 0, 4, 4), box(1, 1, 2, 2), 1, 1},
+		{"subtract inside", Subtract, box(0, 0, 4, 4), box(1, 1, 2, 2), 1, 15},
+		{"union apart", Union, box(0, 0, 1, 1), box(2, 2, 3, 3), 2, 2},
+		{"intersect apart", Intersect, box(0, 0, 1, 1), box(2, 2, 3, 3), 0, 0},
+		{"subtract apart", Subtract, box(0, 0, 1, 1), box(2, 2, 3, 3), 1, 1},
+		{"union degenerate", Union, line, line, 0, 0},
+		{"union degenerate a", Union, line, box(0, 0, 1, 1), 1, 1},
+		{"intersect degenerate", Intersect, line, box(0, 0, 1, 1), 0, 0},
+		{"subtract degenerate a", Subtract, line, box(0, 0, 1, 1), 0, 0},
+		{"subtract degenerate b", Subtract, box(0, 0, 1, 1), line, 1, 1},
+	}
+	for _, test := range tests {
+		r := test.op(test.a, test.b)
+		if len(r) != test.n {
+			t.Errorf("%s: %d polygons %v, want %d", test.name, len(r), r, test.n)
+			continue
+		}
+		if a := totalarea(r); math.Abs(a-test.area) > 1e-4 {
+			t.Errorf("%s: area %g, want %g", test.name, a, test.area)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package deckgen
+
+// Pixel unit methods take coordinates and dimensions in canvas pixels, with the origin
+// at the top left and y increasing downward, as in images and design tools.
+// They are otherwise the same as their percentage counterparts.
+
+// Px converts a point in canvas pixels, with y increasing downward, to canvas percentages.
+func (p *DeckGen) Px(x, y float64) (float64, float64) {
+	return p.pxw(x), 100 - p.pxh(y)
+}
+
+// pxw converts a horizontal length in pixels to a percentage of the canvas width.
+func (p *DeckGen) pxw(v float64) float64 {
+	if p.width == 0 {
+		return v
+	}
+	return v / float64(p.width) * 100
+}
+
+// pxh converts a vertical length in pixels to a percentage of the canvas height.
+func (p *DeckGen) pxh(v float64) float64 {
+	if p.height == 0 {
+		return v
+	}
+	return v / float64(p.height) * 100
+}
+
+// TextPx places plain text aligned at (x,y) in pixels, with size in pixels.
+func (p *DeckGen) TextPx(x, y float64, s, font string, size float64, color string, opacity ...float64) {
+	xp, yp := p.Px(x, y)
+	p.Text(xp, yp, s, font, p.pxw(size), color, opacity...)
+}
+
+// TextMidPx places centered text aligned at (x,y) in pixels, with size in pixels.
+func (p *DeckGen) TextMidPx(x, y float64, s, font string, size float64, color string, opacity ...float64) {
+	xp, yp := p.Px(x, y)
+	p.TextMid(xp, yp, s, font, p.pxw(size), color, opacity...)
+}
+
+// TextEndPx places right-justified text aligned at (x,y) in pixels, with size in pixels.
+func (p *DeckGen) TextEndPx(x, y float64, s, font string, size float64, color string, opacity ...float64) {
+	xp, yp := p.Px(x, y)
+	p.TextEnd(xp, yp, s, font, p.pxw(size), color, opacity...)
+}
+
+// RectPx makes a rectangle centered at (x,y), with (w,h) dimensions, in pixels.
+func (p *DeckGen) RectPx(x, y, w, h float64, color string, opacity ...float64) {
+	xp, yp := p.Px(x, y)
+	p.Rect(xp, yp, p.pxw(w), p.pxh(h), color, opacity...)
+}
+
+// EllipsePx makes an ellipse centered at (x,y), with (w,h) dimensions, in pixels.
+func (p *DeckGen) EllipsePx(x, y, w, h float64, color string, opacity ...float64) {
+	xp, yp := p.Px(x, y)
+	p.Ellipse(xp, yp, p.pxw(w), p.pxh(h), color, opacity...)
+}
+
+// LinePx makes a line from (x1,y1) to (x2,y2), with thickness size, in pixels.
+func (p *DeckGen) LinePx(x1, y1, x2, y2, size float64, color string, opacity ...float64) {
+	xp1, yp1 := p.Px(x1, y1)
+	xp2, yp2 := p.Px(x2, y2)
+	p.Line(xp1, yp1, xp2, yp2, p.pxw(size), color, opacity...)
+}
+
+// PolygonPx makes a polygon with coordinates in pixels.
+func (p *DeckGen) PolygonPx(x, y []float64, color string, opacity ...float64) {
+	if len(x) != len(y) {
+		return
+	}
+	xp := make([]float64, len(x))
+	yp := make([]float64, len(y))
+	for i := range x {
+		xp[i], yp[i] = p.Px(x[i], y[i])
+	}
+	p.Polygon(xp, yp, color, opacity...)
+}
+
+// ImagePx places the named image centered at (x,y) in pixels, with dimensions of (w,h).
+func (p *DeckGen) ImagePx(x, y float64, w, h int, name, link string) {
+	xp, yp := p.Px(x, y)
+	p.Image(xp, yp, w, h, name, link)
+}
@@ -0,0 +1,88 @@
+package deckgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// report slide layout
+const (
+	reporttitley    = 90
+	reporttitlesize = 3.5
+	reportsize      = 1.6
+)
+
+// reportslide begins a slide in the colors and font of the theme, with a title, if any.
+// The title is the slide context's, so that continuation slides repeat it.
+func (p *DeckGen) reportslide(title string) {
+	t := p.themeof(nil)
+	p.StartSlideContext(SlideContext{Title: title, Bg: t.Bg, Fg: t.Fg, Font: t.Font, TitleFont: t.TitleFont})
+}
+
+// hbars draws labelled horizontal bars within area, scaled to the largest value.
+func (p *DeckGen) hbars(area Box, names []string, values []float64, color string) {
+	n := len(values)
+	if n == 0 || len(names) < n {
+		return
+	}
+	largest := 0.0
+	for _, v := range values {
+		largest = math.Max(largest, v)
+	}
+	if largest == 0 {
+		largest = 1
+	}
+	labelw := area.W * 0.3
+	barw := (area.W - labelw) * 0.85
+	rh := area.H / float64(n)
+	size := math.Min(reportsize, rh/p.hpct(1)*0.5)
+	t := p.themeof(nil)
+	for i, v := range values {
+		cy := area.Top() - rh*(float64(i)+0.5)
+		w := barw * v / largest
+		p.TextEnd(area.X+labelw-1, cy-p.hpct(size)/3, names[i], t.Font, size, t.Fg)
+		if w > 0 {
+			p.Rect(area.X+labelw+w/2, cy, w, math.Min(rh*0.7, p.hpct(4)), color)
+		}
+		p.Text(area.X+labelw+w+0.5, cy-p.hpct(size)/3, fmt.Sprintf("%g", v), t.Font, size*0.9, t.Muted)
+	}
+}
+
+// sectorpoints approximates the sector of radius r (in canvas width percentages)
+// centered at (x, y), between angles a1 and a2 degrees, as polygon points.
+func (p *DeckGen) sectorpoints(x, y, r, a1, a2 float64) ([]float64, []float64) {
+	steps := int(math.Ceil(math.Abs(a2-a1)/5)) + 1
+	px := []float64{x}
+	py := []float64{y}
+	for i := 0; i <= steps; i++ {
+		sx, sy := p.polar(x, y, r, a1+(a2-a1)*float64(i)/float64(steps))
+		px = append(px, sx)
+		py = append(py, sy)
+	}
+	return px, py
+}
+
+// pie draws a labelled pie chart centered at (x, y) with radius r.
+func (p *DeckGen) pie(x, y, r float64, names []string, values []float64, colors []string) {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	if total == 0 {
+		return
+	}
+	t := p.themeof(nil)
+	a := 90.0
+	for i, v := range values {
+		sweep := v / total * 360
+		p.Wedge(x, y, r, a, a-sweep, colors[i%len(colors)])
+		lx, ly := p.polar(x, y, r*1.2, a-sweep/2)
+		label := fmt.Sprintf("%s %.0f%%", names[i], v/total*100)
+		if lx < x {
+			p.TextEnd(lx, ly, label, t.Font, reportsize, t.Fg)
+		} else {
+			p.Text(lx, ly, label, t.Font, reportsize, t.Fg)
+		}
+		a -= sweep
+	}
+}
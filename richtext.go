@@ -0,0 +1,105 @@
+package deckgen
+
+import "strings"
+
+// richspan is a run of text in a single style.
+type richspan struct {
+	text         string
+	bold, italic bool
+	code         bool
+	color        string
+}
+
+// parserich splits inline markup into spans: **bold**, *italic*, `code`, and [color]text[/].
+// A backslash makes the next character literal.
+func parserich(s string) []richspan {
+	var spans []richspan
+	var cur richspan
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			cur.text = b.String()
+			spans = append(spans, cur)
+			b.Reset()
+		}
+	}
+	r := []rune(s)
+	for i := 0; i < len(r); i++ {
+		c := r[i]
+		switch {
+		case c == '\\' && i+1 < len(r):
+			i++
+			b.WriteRune(r[i])
+		case c == '`':
+			flush()
+			cur.code = !cur.code
+		case cur.code:
+			b.WriteRune(c)
+		case c == '*' && i+1 < len(r) && r[i+1] == '*':
+			flush()
+			cur.bold = !cur.bold
+			i++
+		case c == '*':
+			flush()
+			cur.italic = !cur.italic
+		case c == '[':
+			end := i + 1
+			for end < len(r) && r[end] != ']' {
+				end++
+			}
+			if end == len(r) {
+				b.WriteRune(c)
+				continue
+			}
+			flush()
+			cur.color = string(r[i+1 : end])
+			if cur.color == "/" {
+				cur.color = ""
+			}
+			i = end
+		default:
+			b.WriteRune(c)
+		}
+	}
+	flush()
+	return spans
+}
+
+// richfont returns the font of a span: bold and italic variants are named by suffixing the
+// font, for example "sans-bold" and "sans-italic"; code is in the mono font.
+func richfont(font, mono string, s richspan) string {
+	switch {
+	case s.code:
+		return mono
+	case s.bold && s.italic:
+		return font + "-bolditalic"
+	case s.bold:
+		return font + "-bold"
+	case s.italic:
+		return font + "-italic"
+	}
+	return font
+}
+
+// RichText places a line of text with inline styling, aligned at (x,y), with the specified font,
+// size and color. The markup is **bold**, *italic*, `code`, and [color]text[/], for example
+// "Sales are **up** [green]12%[/]". Bold and italic text uses the font names with "-bold",
+// "-italic" or "-bolditalic" appended, which the renderer must map to fonts.
+// The width of the text is returned.
+func (p *DeckGen) RichText(x, y float64, s, font string, size float64, color string) float64 {
+	font = p.fontof(font)
+	if font == "" {
+		font = p.themeof(nil).Font
+	}
+	start := x
+	for _, span := range parserich(s) {
+		c := color
+		if span.color != "" {
+			c = span.color
+		}
+		f := richfont(font, p.themeof(nil).Mono, span)
+		p.Text(x, y, span.text, f, size, c)
+		x += textwidth(span.text, f, p.sizeof(size))
+	}
+	return x - start
+}
@@ -0,0 +1,28 @@
+package deckgen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRich(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []richspan
+	}{
+		{"", nil},
+		{"plain", []richspan{{text: "plain"}}},
+		{"a **b** c", []richspan{{text: "a "}, {text: "b", bold: true}, {text: " c"}}},
+		{"*i* ***bi***", []richspan{{text: "i", italic: true}, {text: " "}, {text: "bi", bold: true, italic: true}}},
+		{"`x*y` z", []richspan{{text: "x*y", code: true}, {text: " z"}}},
+		{"[red]hot[/] cold", []richspan{{text: "hot", color: "red"}, {text: " cold"}}},
+		{"[unclosed", []richspan{{text: "[unclosed"}}},
+		{`\*not\* \[x]`, []richspan{{text: "*not* [x]"}}},
+		{"**[blue]b*bi*", []richspan{{text: "b", bold: true, color: "blue"}, {text: "bi", bold: true, italic: true, color: "blue"}}},
+	}
+	for _, test := range tests {
+		if got := parserich(test.s); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parserich(%q) = %+v, want %+v", test.s, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package deckgen
+
+import "math"
+
+// opacityof returns the optional opacity, or the default opacity.
+func (p *DeckGen) opacityof(opacity []float64) float64 {
+	if len(opacity) > 0 {
+		return opacity[0]
+	}
+	return p.defaultopacity()
+}
+
+// NGon makes a regular polygon with the given number of sides, centered at (x,y),
+// with radius r (in canvas width percentages); one vertex is at rotation degrees
+// (0 points right, 90 points up). Opacity is optional.
+func (p *DeckGen) NGon(x, y, r float64, sides int, rotation float64, color string, opacity ...float64) {
+	if sides < 3 {
+		return
+	}
+	xs := make([]float64, sides)
+	ys := make([]float64, sides)
+	for i := range xs {
+		xs[i], ys[i] = p.polar(x, y, r, rotation+float64(i)*360/float64(sides))
+	}
+	p.Polygon(xs, ys, color, p.opacityof(opacity))
+}
+
+// Star makes a star with the given number of points, centered at (x,y), with outer and inner
+// radii (in canvas width percentages), with a point at the top. Opacity is optional.
+func (p *DeckGen) Star(x, y, outer, inner float64, points int, color string, opacity ...float64) {
+	if points < 2 {
+		return
+	}
+	n := points * 2
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i := range xs {
+		r := outer
+		if i%2 == 1 {
+			r = inner
+		}
+		xs[i], ys[i] = p.polar(x, y, r, 90+float64(i)*180/float64(points))
+	}
+	p.Polygon(xs, ys, color, p.opacityof(opacity))
+}
+
+// arrowhead returns the polygon of an arrowhead pointing to (x2,y2) along the line from (x1,y1),
+// with width and length in canvas width percentages, and the point where the shaft meets the head.
+func (p *DeckGen) arrowhead(x1, y1, x2, y2, width, length float64) ([]float64, []float64, float64, float64) {
+	dx, dy := x2-x1, p.wpct(y2-y1)
+	d := math.Hypot(dx, dy)
+	if d == 0 {
+		return nil, nil, x2, y2
+	}
+	ux, uy := dx/d, dy/d
+	bx, by := x2-ux*length, p.wpct(y2)-uy*length
+	hw := width / 2
+	xs := []float64{x2, bx - uy*hw, bx + uy*hw}
+	ys := []float64{y2, p.hpct(by + ux*hw), p.hpct(by - ux*hw)}
+	return xs, ys, bx, p.hpct(by)
+}
+
+// Arrow makes an arrow from (x1,y1) to (x2,y2), with a shaft of the given thickness, and a head
+// of headWidth and headLength (in canvas width percentages) at (x2,y2). Opacity is optional.
+func (p *DeckGen) Arrow(x1, y1, x2, y2, thickness, headWidth, headLength float64, color string, opacity ...float64) {
+	op := p.opacityof(opacity)
+	xs, ys, bx, by := p.arrowhead(x1, y1, x2, y2, headWidth, headLength)
+	if xs == nil {
+		return
+	}
+	p.Line(x1, y1, bx, by, thickness, color, op)
+	p.Polygon(xs, ys, color, op)
+}
+
+// roundedbox returns the polygon of a box with corners rounded with radius r (in canvas width percentages).
+func (p *DeckGen) roundedbox(b Box, r float64) ([]float64, []float64) {
+	r = math.Min(r, math.Min(b.W/2, p.wpct(b.H)/2))
+	rh := p.hpct(r)
+	corners := [4][3]float64{
+		{b.Right() - r, b.Top() - rh, 0},
+		{b.X + r, b.Top() - rh, 90},
+		{b.X + r, b.Y + rh, 180},
+		{b.Right() - r, b.Y + rh, 270},
+	}
+	const steps = 6
+	var xs, ys []float64
+	for _, c := range corners {
+		for i := 0; i <= steps; i++ {
+			x, y := p.polar(c[0], c[1], r, c[2]+float64(i)*90/steps)
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+	return xs, ys
+}
+
+// Callout makes a speech bubble: a rounded box centered at (x,y), with dimensions (w,h), and a tail
+// pointing to (px,py), filled with bg, with the text centered in fg.
+func (p *DeckGen) Callout(x, y, w, h, px, py float64, text, font string, size float64, bg, fg string) {
+	dx, dy := px-x, p.wpct(py-y)
+	if d := math.Hypot(dx, dy); d > 0 {
+		tw := math.Min(w, p.wpct(h)) / 6
+		ox, oy := -dy/d*tw, dx/d*tw
+		p.Polygon([]float64{x + ox, px, x - ox}, []float64{y + p.hpct(oy), py, y - p.hpct(oy)}, bg, 100)
+	}
+	b := centerbox(x, y, w, h)
+	xs, ys := p.roundedbox(b, math.Min(w, p.wpct(h))/5)
+	p.Polygon(xs, ys, bg, 100)
+	p.TextMid(x, y-p.hpct(size)*0.35, text, font, size, fg)
+}
+
+// Brace makes a curly brace centered at (x,y), spanning length along its axis, with its point
+// at depth from the back. The orientation is the direction of the point: "left", "right", "up" or "down";
+// left and right braces are vertical, up and down braces are horizontal.
+func (p *DeckGen) Brace(x, y, length, depth float64, orientation, color string) {
+	// (u, v) are distances along the axis and towards the point.
+	pt := func(u, v float64) (float64, float64) {
+		switch orientation {
+		case "left":
+			return x - v, y + u
+		case "up":
+			return x + u, y + v
+		case "down":
+			return x + u, y - v
+		default:
+			return x + v, y + u
+		}
+	}
+	l, d := length/2, depth
+	segments := [4][6]float64{
+		{l, 0, l, d / 2, l / 2, d / 2},
+		{l / 2, d / 2, 0, d / 2, 0, d},
+		{0, d, 0, d / 2, -l / 2, d / 2},
+		{-l / 2, d / 2, -l, d / 2, -l, 0},
+	}
+	thick := math.Max(0.1, depth/10)
+	for _, s := range segments {
+		x1, y1 := pt(s[0], s[1])
+		x2, y2 := pt(s[2], s[3])
+		x3, y3 := pt(s[4], s[5])
+		p.Curve(x1, y1, x2, y2, x3, y3, thick, color)
+	}
+}
+
+// Pill makes a capsule centered at (x,y), with dimensions (w,h): a rectangle with
+// semicircular ends on its shorter sides. Opacity is optional.
+func (p *DeckGen) Pill(x, y, w, h float64, color string, opacity ...float64) {
+	op := p.opacityof(opacity)
+	d := p.wpct(h)
+	if d <= w {
+		p.Rect(x, y, w-d, h, color, op)
+		p.Circle(x-(w-d)/2, y, d, color, op)
+		p.Circle(x+(w-d)/2, y, d, color, op)
+		return
+	}
+	off := (h - p.hpct(w)) / 2
+	p.Rect(x, y, w, h-p.hpct(w), color, op)
+	p.Circle(x, y-off, w, color, op)
+	p.Circle(x, y+off, w, color, op)
+}
+
+// Wedge makes a filled sector of a circle centered at (x,y), with radius r (in canvas width percentages),
+// from angle a1 to a2 degrees (0 points right, 90 points up). Opacity is optional.
+func (p *DeckGen) Wedge(x, y, r, a1, a2 float64, color string, opacity ...float64) {
+	xs, ys := p.sectorpoints(x, y, r, a1, a2)
+	p.Polygon(xs, ys, color, p.opacityof(opacity))
+}
@@ -0,0 +1,29 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSnap(t *testing.T) {
+	tests := []struct {
+		snap, x, w, h float64
+		want          string
+	}{
+		{0, 10.3, 0.2, 0.1, `xp="10.30" yp="20.00" wp="0.20" hp="0.10"`},
+		{0.5, 10.3, 0.2, 0.1, `xp="10.50" yp="20.00" wp="0.50" hp="0.50"`},
+		{0.5, 10.3, 2.2, 0, `xp="10.50" yp="20.00" wp="2.00" hp="0.00"`},
+		{1, 10.6, 0.4, 3.4, `xp="11.00" yp="20.00" wp="1.00" hp="3.00"`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.SetSnap(test.snap)
+		p.Rect(test.x, 20, test.w, test.h, "red")
+		p.Flush()
+		if out := buf.String(); !strings.Contains(out, test.want) {
+			t.Errorf("snap %v: %s has no %s", test.snap, out, test.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package deckgen
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// SlideTitle returns the text of the largest text element on a slide, taken to be its title.
+func SlideTitle(s Slide) string {
+	title, size := "", 0.0
+	for _, t := range s.Text {
+		if text := strings.TrimSpace(t.Tdata); text != "" && t.Sp > size {
+			title, size = text, t.Sp
+		}
+	}
+	return title
+}
+
+// slug converts a title to a short, file name safe form.
+func slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// WriteDeck writes a deck structure as markup.
+// Elements are grouped by type within each slide, so their stacking order may differ from the source.
+func WriteDeck(path string, d *Deck) error {
+	data, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Split writes each slide of a deck as a single slide deck file in dir, keeping
+// the canvas and metadata of the original. Files are named by slide number and,
+// when the slide has one, its title (for example "003-results.xml").
+// The names of the files written are returned.
+func Split(d *Deck, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(d.Slide))
+	for i, s := range d.Slide {
+		name := fmt.Sprintf("%03d", i+1)
+		if t := slug(SlideTitle(s)); t != "" {
+			name += "-" + t
+		}
+		path := filepath.Join(dir, name+".xml")
+		single := *d
+		single.Slide = []Slide{s}
+		if err := WriteDeck(path, &single); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
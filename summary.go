@@ -0,0 +1,185 @@
+package deckgen
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ColumnStats are the summary statistics of a CSV column.
+// Numeric statistics are only meaningful when Numeric is true.
+type ColumnStats struct {
+	Name                   string
+	Count, Missing         int
+	Numeric                bool
+	Mean, Median, Min, Max float64
+	Values                 []float64 // the numeric values, in row order
+}
+
+// ColumnSummary reads CSV with a header row, and computes statistics for each column.
+// A column is numeric when all of its non-empty values are numbers.
+func ColumnSummary(r io.Reader) ([]ColumnStats, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty CSV")
+	}
+	header := records[0]
+	stats := make([]ColumnStats, len(header))
+	for i, h := range header {
+		s := ColumnStats{Name: h, Numeric: true}
+		for _, rec := range records[1:] {
+			v := ""
+			if i < len(rec) {
+				v = strings.TrimSpace(rec[i])
+			}
+			if v == "" || strings.EqualFold(v, "NA") || strings.EqualFold(v, "null") {
+				s.Missing++
+				continue
+			}
+			s.Count++
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				s.Numeric = false
+				continue
+			}
+			s.Values = append(s.Values, n)
+		}
+		if s.Count == 0 {
+			s.Numeric = false
+		}
+		if s.Numeric {
+			sorted := append([]float64(nil), s.Values...)
+			sort.Float64s(sorted)
+			s.Min, s.Max = sorted[0], sorted[len(sorted)-1]
+			s.Mean = mean(sorted)
+			if n := len(sorted); n%2 == 1 {
+				s.Median = sorted[n/2]
+			} else {
+				s.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+			}
+		}
+		stats[i] = s
+	}
+	return stats, nil
+}
+
+// histogram counts values into n equal-width bins.
+func histogram(values []float64, n int) []float64 {
+	bins := make([]float64, n)
+	if len(values) == 0 {
+		return bins
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(n))
+		}
+		if i >= n {
+			i = n - 1
+		}
+		bins[i]++
+	}
+	return bins
+}
+
+// sparkline draws values as a small line chart filling box b.
+func (p *DeckGen) sparkline(b Box, values []float64, color string) {
+	n := len(values)
+	if n < 2 {
+		return
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i, v := range values {
+		x[i] = b.X + b.W*float64(i)/float64(n-1)
+		y[i] = b.Y + b.H*(v-lo)/(hi-lo)
+	}
+	if n == 2 {
+		p.Line(x[0], y[0], x[1], y[1], 0.2, color)
+		return
+	}
+	p.Polyline(x, y, 0.2, color, 100)
+}
+
+// statfmt formats a statistic compactly.
+func statfmt(v float64) string {
+	return strconv.FormatFloat(v, 'g', 5, 64)
+}
+
+// distrows is the number of rows of distributions on each slide.
+const distrows = 4
+
+// Summarize reads CSV with a header row, and generates a slide with a table of
+// per-column count, missing, mean, median, minimum and maximum, followed by
+// slides of sparklines showing the distribution of each numeric column,
+// continued after twelve columns.
+func (p *DeckGen) Summarize(r io.Reader) error {
+	stats, err := ColumnSummary(r)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, len(stats))
+	for i, s := range stats {
+		rows[i] = []string{s.Name, strconv.Itoa(s.Count), strconv.Itoa(s.Missing), "", "", "", ""}
+		if s.Numeric {
+			rows[i][3], rows[i][4], rows[i][5], rows[i][6] = statfmt(s.Mean), statfmt(s.Median), statfmt(s.Min), statfmt(s.Max)
+		}
+	}
+	p.reportslide("Summary Statistics")
+	p.Table(5, 82, 90, []string{"Column", "Count", "Missing", "Mean", "Median", "Min", "Max"}, rows,
+		TableStyle{Size: 1.3, Align: []string{"begin", "end", "end", "end", "end", "end", "end"}})
+	p.EndSlide()
+
+	var numeric []ColumnStats
+	for _, s := range stats {
+		if s.Numeric {
+			numeric = append(numeric, s)
+		}
+	}
+	if len(numeric) == 0 {
+		return nil
+	}
+	t := p.themeof(nil)
+	p.reportslide("Distributions")
+	cols := 3
+	area := Box{X: 5, Y: 5, W: 90, H: 75}
+	for start := 0; start < len(numeric); start += cols * distrows {
+		if start > 0 {
+			p.continueslide()
+		}
+		end := start + cols*distrows
+		if end > len(numeric) {
+			end = len(numeric)
+		}
+		rowsn := (end - start + cols - 1) / cols
+		cw, rh := area.W/float64(cols), math.Min(area.H/float64(rowsn), 20)
+		for i, s := range numeric[start:end] {
+			x := area.X + cw*float64(i%cols)
+			top := area.Top() - rh*float64(i/cols)
+			p.Text(x+1, top-p.hpct(1.4), s.Name, t.Font, 1.4, t.Fg)
+			p.sparkline(Box{X: x + 1, Y: top - rh*0.85, W: cw - 4, H: rh*0.85 - p.hpct(2.8)}, histogram(s.Values, 12), t.Accent)
+			p.Text(x+1, top-rh*0.85-p.hpct(1.5), statfmt(s.Min), t.Font, 0.9, t.Muted)
+			p.TextEnd(x+cw-3, top-rh*0.85-p.hpct(1.5), statfmt(s.Max), t.Font, 0.9, t.Muted)
+		}
+	}
+	p.EndSlide()
+	return nil
+}
@@ -0,0 +1,61 @@
+package deckgen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeDistributions(t *testing.T) {
+	tests := []struct {
+		columns int
+		slides  int // of distributions
+	}{
+		{1, 1},
+		{12, 1},
+		{13, 2},
+		{60, 5},
+	}
+	for _, test := range tests {
+		var csv strings.Builder
+		for i := 0; i < test.columns; i++ {
+			if i > 0 {
+				csv.WriteString(",")
+			}
+			fmt.Fprintf(&csv, "c%d", i)
+		}
+		for r := 0; r < 5; r++ {
+			csv.WriteString("\n")
+			for i := 0; i < test.columns; i++ {
+				if i > 0 {
+					csv.WriteString(",")
+				}
+				fmt.Fprintf(&csv, "%d", r*i)
+			}
+		}
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.StartDeck()
+		if err := p.Summarize(strings.NewReader(csv.String())); err != nil {
+			t.Fatalf("%d columns: %v", test.columns, err)
+		}
+		p.EndDeck()
+		out := buf.String()
+		if n := strings.Count(out, ">Distributions"); n != test.slides {
+			t.Errorf("%d columns: %d distribution slides, want %d", test.columns, n, test.slides)
+		}
+		if test.slides > 1 && !strings.Contains(out, ">Distributions (continued)<") {
+			t.Errorf("%d columns: no continued distributions slide", test.columns)
+		}
+		dist := out[strings.Index(out, ">Distributions"):]
+		for _, m := range regexp.MustCompile(`<text xp="[^"]*" yp="([^"]*)"`).FindAllStringSubmatch(dist, -1) {
+			if y, _ := strconv.ParseFloat(m[1], 64); y < 0 {
+				t.Errorf("%d columns: text below the slide at y %g", test.columns, y)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package deckgen
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TableStyle controls the appearance of generated tables.
+// Zero values select defaults, from the deck's theme where noted.
+type TableStyle struct {
+	Font        string   // cell font (default: theme font)
+	HeaderFont  string   // header font (default: the cell font)
+	Size        float64  // text size (default: 1.5)
+	Color       string   // cell text color (default: theme foreground)
+	HeaderColor string   // header text color (default: theme background)
+	HeaderBg    string   // header background (default: theme accent)
+	StripeColor string   // background of alternate rows; "none" disables striping (default: lightgray)
+	Align       []string // per-column alignment: begin, center, or end
+	Padding     float64  // cell padding, in canvas width percentages (default: half the text size)
+	Bottom      float64  // rows continue on a new slide below this y coordinate (default: 5)
+	GridColor   string   // color of the lines between cells (default: no lines)
+	GridWidth   float64  // width of the grid lines (default: 0.1)
+}
+
+// tabledefaults fills in the unset style fields, from the theme t where applicable.
+func tabledefaults(s TableStyle, t Theme) TableStyle {
+	if s.Font == "" {
+		s.Font = t.Font
+	}
+	if s.HeaderFont == "" {
+		s.HeaderFont = s.Font
+	}
+	if s.Size == 0 {
+		s.Size = 1.5
+	}
+	if s.Color == "" {
+		s.Color = t.Fg
+	}
+	if s.HeaderColor == "" {
+		s.HeaderColor = t.Bg
+	}
+	if s.HeaderBg == "" {
+		s.HeaderBg = t.Accent
+	}
+	if s.StripeColor == "" {
+		s.StripeColor = "lightgray"
+	}
+	if s.Padding == 0 {
+		s.Padding = s.Size / 2
+	}
+	if s.Bottom == 0 {
+		s.Bottom = 5
+	}
+	if s.GridWidth == 0 {
+		s.GridWidth = 0.1
+	}
+	return s
+}
+
+// columnwidths divides the width w among the columns in proportion to their widest cell.
+// If w is 0, each column is as wide as its widest cell.
+func columnwidths(w float64, headers []string, rows [][]string, s TableStyle) []float64 {
+	n := len(headers)
+	for _, r := range rows {
+		if len(r) > n {
+			n = len(r)
+		}
+	}
+	widths := make([]float64, n)
+	measure := func(i int, text, font string) {
+		widths[i] = math.Max(widths[i], textwidth(text, font, s.Size)+2*s.Padding)
+	}
+	for i, h := range headers {
+		measure(i, h, s.HeaderFont)
+	}
+	for _, r := range rows {
+		for i, c := range r {
+			measure(i, c, s.Font)
+		}
+	}
+	total := 0.0
+	for _, cw := range widths {
+		total += cw
+	}
+	if total == 0 || w <= 0 {
+		return widths
+	}
+	// columns are scaled to fit, but no column is squeezed below its share of a tenth of the width
+	floor := w / float64(n) / 10
+	for i := range widths {
+		widths[i] = math.Max(widths[i]*w/total, floor)
+	}
+	return widths
+}
+
+// celllines returns the number of wrapped lines needed for a cell.
+func celllines(text, font string, size, width float64) int {
+	tw := textwidth(text, font, size)
+	if width <= 0 || tw <= width {
+		return 1
+	}
+	return int(math.Ceil(tw / width))
+}
+
+// rowheight returns the height of a row, allowing for wrapped cells.
+func (p *DeckGen) rowheight(cells []string, font string, widths []float64, s TableStyle) float64 {
+	lines := 1
+	for i, c := range cells {
+		if i < len(widths) {
+			if n := celllines(c, font, s.Size, widths[i]-2*s.Padding); n > lines {
+				lines = n
+			}
+		}
+	}
+	lh := p.hpct(s.Size)
+	return lh + float64(lines-1)*lh*leading(0) + 2*p.hpct(s.Padding)
+}
+
+// tablerow draws one row of cells at top y, returning its height.
+func (p *DeckGen) tablerow(x, y float64, cells []string, widths []float64, font, color, bg string, s TableStyle) float64 {
+	h := p.rowheight(cells, font, widths, s)
+	if bg != "" {
+		total := 0.0
+		for _, cw := range widths {
+			total += cw
+		}
+		p.Rect(x+total/2, y-h/2, total, h, bg)
+	}
+	if s.GridColor != "" {
+		p.tablegrid(x, y, h, widths, s)
+	}
+	cx := x
+	baseline := y - p.hpct(s.Padding) - p.hpct(s.Size)*0.8
+	for i, cw := range widths {
+		if i < len(cells) {
+			c := cells[i]
+			align := ""
+			if i < len(s.Align) {
+				align = s.Align[i]
+			}
+			switch {
+			case celllines(c, font, s.Size, cw-2*s.Padding) > 1:
+				p.TextBlock(cx+s.Padding, baseline, c, font, s.Size, cw-2*s.Padding, color)
+			case align == "center" || align == "middle" || align == "c":
+				p.TextMid(cx+cw/2, baseline, c, font, s.Size, color)
+			case align == "end" || align == "right" || align == "e":
+				p.TextEnd(cx+cw-s.Padding, baseline, c, font, s.Size, color)
+			default:
+				p.Text(cx+s.Padding, baseline, c, font, s.Size, color)
+			}
+		}
+		cx += cw
+	}
+	return h
+}
+
+// tablegrid draws the lines below and beside the cells of a row of height h at top y.
+func (p *DeckGen) tablegrid(x, y, h float64, widths []float64, s TableStyle) {
+	cx := x
+	p.Line(cx, y, cx, y-h, s.GridWidth, s.GridColor)
+	for _, cw := range widths {
+		cx += cw
+		p.Line(cx, y, cx, y-h, s.GridWidth, s.GridColor)
+	}
+	p.Line(x, y-h, cx, y-h, s.GridWidth, s.GridColor)
+}
+
+// Table draws a table whose top left corner is at (x, y) with overall width w.
+// Column widths are computed from the cell contents, in proportion to fill w, or,
+// if w is 0, as measured. Long cells wrap, and
+// rows that would fall below the style's bottom margin continue on new slides,
+// with the header repeated, at the top, or below the title of a titled slide, marked "(continued)". Table returns the y coordinate below the last row.
+func (p *DeckGen) Table(x, y, w float64, headers []string, rows [][]string, style TableStyle) float64 {
+	s := tabledefaults(style, p.themeof(nil))
+	// rows are continued by the table itself
+	save := p.paginate
+	p.paginate = 0
+	defer func() { p.paginate = save }()
+	widths := columnwidths(w, headers, rows, s)
+	header := func(top float64) float64 {
+		if s.GridColor != "" {
+			total := 0.0
+			for _, cw := range widths {
+				total += cw
+			}
+			p.Line(x, top, x+total, top, s.GridWidth, s.GridColor)
+		}
+		if len(headers) == 0 {
+			return top
+		}
+		return top - p.tablerow(x, top, headers, widths, s.HeaderFont, s.HeaderColor, s.HeaderBg, s)
+	}
+	cur := header(y)
+	onslide := 0
+	for i, r := range rows {
+		if onslide > 0 && cur-p.rowheight(r, s.Font, widths, s) < s.Bottom {
+			top := 95.0
+			if p.ctx.Title != "" {
+				p.continueslide()
+				top = continuedy
+			} else {
+				ctx := p.ctx
+				p.EndSlide()
+				p.StartSlide(p.slidecolors...)
+				p.ctx = ctx
+			}
+			cur = header(top)
+			onslide = 0
+		}
+		bg := ""
+		if i%2 == 1 && s.StripeColor != "none" {
+			bg = s.StripeColor
+		}
+		cur -= p.tablerow(x, cur, r, widths, s.Font, s.Color, bg, s)
+		onslide++
+	}
+	return cur
+}
+
+// CSVTableOptions are optional settings of TableFromCSV.
+type CSVTableOptions struct {
+	Title    string     // title of the first slide
+	NoHeader bool       // the first record is data, not column names
+	Comma    rune       // field delimiter (default: comma)
+	Style    TableStyle // table style; unset alignments are end for numeric columns
+}
+
+// numericcolumns reports, for each column, whether all of its non-empty cells are numbers.
+func numericcolumns(rows [][]string, n int) []bool {
+	numeric := make([]bool, n)
+	for i := range numeric {
+		numeric[i] = true
+		empty := true
+		for _, r := range rows {
+			if i >= len(r) || strings.TrimSpace(r[i]) == "" {
+				continue
+			}
+			empty = false
+			if _, err := strconv.ParseFloat(strings.TrimSpace(r[i]), 64); err != nil {
+				numeric[i] = false
+				break
+			}
+		}
+		if empty {
+			numeric[i] = false
+		}
+	}
+	return numeric
+}
+
+// TableFromCSV reads CSV, with a header row unless NoHeader is set, and makes slides with a table
+// of the records. Records that do not fit on a slide continue on the next, below the header.
+func (p *DeckGen) TableFromCSV(r io.Reader, opts ...CSVTableOptions) error {
+	var o CSVTableOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if o.Comma != 0 {
+		cr.Comma = o.Comma
+	}
+	records, err := cr.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("empty CSV")
+	}
+	var headers []string
+	rows := records
+	if !o.NoHeader {
+		headers, rows = records[0], records[1:]
+	}
+	n := len(headers)
+	for _, r := range rows {
+		if len(r) > n {
+			n = len(r)
+		}
+	}
+	style := o.Style
+	if len(style.Align) < n {
+		align := make([]string, n)
+		copy(align, style.Align)
+		for i, num := range numericcolumns(rows, n) {
+			if align[i] == "" && num {
+				align[i] = "end"
+			}
+		}
+		style.Align = align
+	}
+	top := 95.0
+	if o.Title != "" {
+		top = 82
+	}
+	p.reportslide(o.Title)
+	p.Table(5, top, 90, headers, rows, style)
+	p.EndSlide()
+	return nil
+}
@@ -0,0 +1,49 @@
+package deckgen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTableContinuation(t *testing.T) {
+	var csv strings.Builder
+	csv.WriteString("name,value\n")
+	for i := 0; i < 60; i++ {
+		fmt.Fprintf(&csv, "row%d,%d\n", i, i)
+	}
+	tests := []struct {
+		name  string
+		title string
+		want  []string
+		top   string // y of the continued header band
+	}{
+		{name: "titled", title: "Data", want: []string{">Data (continued)<"}, top: "78.00"},
+		{name: "untitled", top: "93.00"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.StartDeck()
+		if err := p.TableFromCSV(strings.NewReader(csv.String()), CSVTableOptions{Title: test.title}); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		p.EndDeck()
+		out := buf.String()
+		slides := strings.Split(out, "<slide")
+		if len(slides) < 3 {
+			t.Fatalf("%s: %d slides, want at least 2", test.name, len(slides)-1)
+		}
+		for _, w := range test.want {
+			if !strings.Contains(out, w) {
+				t.Errorf("%s: output lacks %q", test.name, w)
+			}
+		}
+		// the header band of the continued table is centered in the first row
+		if !regexp.MustCompile(`^[^<]*>(<text [^>]*>[^<]*</text>)?<rect xp="50.00" yp="` + test.top + `"`).MatchString(slides[2]) {
+			t.Errorf("%s: continued table does not start at %s:\n%s", test.name, test.top, slides[2][:200])
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package deckgen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tablecolumn describes how a struct field is shown in a table.
+type tablecolumn struct {
+	index  []int
+	header string
+	align  string
+	format string
+}
+
+// parsecolumn reads the deck struct tag of a field: `deck:"Header,align=end,format=%.2f"`.
+// A tag of "-" omits the field.
+func parsecolumn(f reflect.StructField) (tablecolumn, bool) {
+	c := tablecolumn{index: f.Index, header: f.Name}
+	tag := f.Tag.Get("deck")
+	if tag == "-" {
+		return c, false
+	}
+	for i, part := range strings.Split(tag, ",") {
+		switch {
+		case i == 0 && part != "":
+			c.header = part
+		case strings.HasPrefix(part, "align="):
+			c.align = strings.TrimPrefix(part, "align=")
+		case strings.HasPrefix(part, "format="):
+			c.format = strings.TrimPrefix(part, "format=")
+		}
+	}
+	if c.align == "" {
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			c.align = "end"
+		}
+	}
+	return c, true
+}
+
+// TableOf draws a table on the current slide from a slice of structs (or pointers to structs),
+// with one row per element. The columns name the fields to show, in order; by default
+// all exported fields are shown. Struct tags control the column headers, alignment and
+// number formatting, for example `deck:"Revenue,align=end,format=$%.2f"`; `deck:"-"` omits a field.
+func (p *DeckGen) TableOf(slice interface{}, columns ...string) error {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Errorf("TableOf: %T is not a slice", slice)
+	}
+	et := v.Type().Elem()
+	ptr := et.Kind() == reflect.Ptr
+	if ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return fmt.Errorf("TableOf: %T is not a slice of structs", slice)
+	}
+	var cols []tablecolumn
+	if len(columns) == 0 {
+		for i := 0; i < et.NumField(); i++ {
+			f := et.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			if c, ok := parsecolumn(f); ok {
+				cols = append(cols, c)
+			}
+		}
+	} else {
+		for _, name := range columns {
+			f, ok := et.FieldByName(name)
+			if !ok {
+				return fmt.Errorf("TableOf: %s has no field %s", et, name)
+			}
+			if f.PkgPath != "" {
+				return fmt.Errorf("TableOf: field %s of %s is unexported", name, et)
+			}
+			c, _ := parsecolumn(f)
+			cols = append(cols, c)
+		}
+	}
+	headers := make([]string, len(cols))
+	align := make([]string, len(cols))
+	for i, c := range cols {
+		headers[i], align[i] = c.header, c.align
+	}
+	rows := make([][]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i)
+		if ptr {
+			if e.IsNil() {
+				continue
+			}
+			e = e.Elem()
+		}
+		row := make([]string, len(cols))
+		for j, c := range cols {
+			// fields of nil embedded structs are shown empty
+			fv, err := e.FieldByIndexErr(c.index)
+			if err != nil {
+				continue
+			}
+			if c.format != "" {
+				row[j] = fmt.Sprintf(c.format, fv.Interface())
+			} else {
+				row[j] = fmt.Sprint(fv.Interface())
+			}
+		}
+		rows = append(rows, row)
+	}
+	p.Table(5, 82, 90, headers, rows, TableStyle{Align: align})
+	return nil
+}
@@ -0,0 +1,51 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type tableofinner struct {
+	Region string
+}
+
+type tableofrow struct {
+	Name    string
+	Revenue float64 `deck:"Revenue,align=end,format=%.2f"`
+	Secret  string  `deck:"-"`
+	hidden  int
+	*tableofinner
+}
+
+func TestTableOf(t *testing.T) {
+	rows := []tableofrow{
+		{Name: "north", Revenue: 1.5, tableofinner: &tableofinner{Region: "N"}},
+		{Name: "south", Revenue: 2},
+	}
+	var buf bytes.Buffer
+	p := NewSlides(&buf, 1024, 768)
+	if err := p.TableOf(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.TableOf(rows, "Name", "Region"); err != nil {
+		t.Fatal(err)
+	}
+	p.Flush()
+	for _, want := range []string{">north<", ">1.50<", ">2.00<", ">N<"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("TableOf output has no %s", want)
+		}
+	}
+	if strings.Contains(buf.String(), "Secret") {
+		t.Errorf("TableOf shows an omitted field")
+	}
+	for _, cols := range [][]string{{"hidden"}, {"Missing"}} {
+		if err := p.TableOf(rows, cols...); err == nil {
+			t.Errorf("TableOf(%v): no error", cols)
+		}
+	}
+	if err := p.TableOf(42); err == nil {
+		t.Errorf("TableOf(42): no error")
+	}
+}
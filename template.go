@@ -0,0 +1,74 @@
+package deckgen
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+)
+
+// placeholder matches template placeholders such as {{title}}, and markup slots such as {{&chart}}.
+var placeholder = regexp.MustCompile(`{{\s*(&?)\s*(\w+)\s*}}`)
+
+// SlideTemplate is recorded slide markup containing named placeholders.
+type SlideTemplate struct {
+	markup []byte
+}
+
+// Capture runs fn with output redirected, and returns the markup it generated.
+// It is useful for building placeholder values such as charts.
+func (p *DeckGen) Capture(fn func(*DeckGen)) string {
+	var buf bytes.Buffer
+	dest, inslide, layer, layers := p.dest, p.inslide, p.layer, p.layers
+	p.dest, p.inslide, p.layer, p.layers = &buf, false, 0, nil
+	fn(p)
+	p.dest, p.inslide, p.layer, p.layers = dest, inslide, layer, layers
+	return buf.String()
+}
+
+// Placeholder emits a named slot, to be replaced by markup when a template is filled.
+func (p *DeckGen) Placeholder(name string) {
+	io.WriteString(p.out(), "{{&"+name+"}}")
+}
+
+// DefineTemplate records the markup generated by fn as a template. Placeholders are
+// written as {{name}} in element text or attributes, for example in
+// p.Text(10, 90, "{{title}}", ...) or p.Image(50, 50, 640, 480, "{{image}}", ""),
+// or with Placeholder for a slot to be filled with markup, such as a chart made with Capture.
+func (p *DeckGen) DefineTemplate(fn func(*DeckGen)) *SlideTemplate {
+	return &SlideTemplate{markup: []byte(p.Capture(fn))}
+}
+
+// FillTemplate emits the template's markup with each placeholder replaced by its value.
+// Values are escaped, like other text, except those of slots made with Placeholder,
+// which are inserted as markup. Placeholders without a value are removed.
+func (p *DeckGen) FillTemplate(t *SlideTemplate, values map[string]string) {
+	out := placeholder.ReplaceAllFunc(t.markup, func(m []byte) []byte {
+		sm := placeholder.FindSubmatch(m)
+		v := values[string(sm[2])]
+		if len(sm[1]) == 0 {
+			v = p.esc(v)
+		}
+		return []byte(v)
+	})
+	p.out().Write(out)
+}
+
+// DefineMaster records the markup generated by fn, such as a background, logo or footer,
+// as the named master slide, for use with StartSlideWithMaster.
+func (p *DeckGen) DefineMaster(name string, fn func(*DeckGen)) {
+	if p.masters == nil {
+		p.masters = map[string]string{}
+	}
+	p.masters[name] = p.Capture(fn)
+}
+
+// StartSlideWithMaster begins a slide, like StartSlide, with the elements of the named master
+// slide placed behind its content. An undefined master adds nothing.
+func (p *DeckGen) StartSlideWithMaster(name string, colors ...string) {
+	p.StartSlide(colors...)
+	if m, ok := p.masters[name]; ok {
+		p.inlayer(BackgroundLayer, func(p *DeckGen) {
+			io.WriteString(p.out(), m)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFillTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewSlides(&buf, 1024, 768)
+	tmpl := p.DefineTemplate(func(p *DeckGen) {
+		p.Text(10, 90, "{{title}}", "sans", 3, "black")
+		p.Placeholder("chart")
+		p.Text(10, 10, "{{missing}}", "sans", 1, "gray")
+	})
+	chart := p.Capture(func(p *DeckGen) { p.Rect(50, 50, 10, 10, "red") })
+	p.FillTemplate(tmpl, map[string]string{"title": "R&D <2024>", "chart": chart})
+	p.Flush()
+	out := buf.String()
+	for _, want := range []string{">R&amp;D &lt;2024&gt;</text>", `<rect xp="50.00"`, `color="gray" type=""></text>`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FillTemplate output %s has no %s", out, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package deckgen
+
+import "strings"
+
+// textlines places lines of text from baseline y downward, aligned at x, and returns the baseline of the next line.
+func (p *DeckGen) textlines(x, y float64, lines []string, font string, size, spacing float64, color, align string) float64 {
+	step := p.hpct(p.sizeof(size) * leading(spacing))
+	for _, s := range lines {
+		switch align {
+		case "center":
+			p.TextMid(x, y, s, font, size, color)
+		case "end":
+			p.TextEnd(x, y, s, font, size, color)
+		default:
+			p.Text(x, y, s, font, size, color)
+		}
+		y -= step
+	}
+	return y
+}
+
+// TextLines places lines of left-aligned text, starting with the baseline of the first line at (x,y),
+// spaced by leading times the size (default 2). The baseline of the next line is returned.
+func (p *DeckGen) TextLines(x, y float64, lines []string, font string, size, leading float64, color string) float64 {
+	return p.textlines(x, y, lines, font, size, leading, color, "")
+}
+
+// TextLinesMid places lines of text centered at x, like TextLines.
+func (p *DeckGen) TextLinesMid(x, y float64, lines []string, font string, size, leading float64, color string) float64 {
+	return p.textlines(x, y, lines, font, size, leading, color, "center")
+}
+
+// TextLinesEnd places lines of text right-justified at x, like TextLines.
+func (p *DeckGen) TextLinesEnd(x, y float64, lines []string, font string, size, leading float64, color string) float64 {
+	return p.textlines(x, y, lines, font, size, leading, color, "end")
+}
+
+// wraplines breaks s into lines no wider than width, measured with the metrics of font at size.
+// Lines are broken between words, and within words too long to fit; newlines are kept.
+func wraplines(s, font string, size, width float64) []string {
+	var lines []string
+	space := textwidth(" ", font, size)
+	for _, para := range strings.Split(s, "\n") {
+		line, lw := "", 0.0
+		for _, word := range strings.Fields(para) {
+			ww := textwidth(word, font, size)
+			for ww > width && len([]rune(word)) > 1 {
+				if line != "" {
+					lines = append(lines, line)
+					line, lw = "", 0
+				}
+				part, pw := "", 0.0
+				for _, r := range word {
+					rw := runewidth(r, font) * size
+					if pw+rw > width && part != "" {
+						break
+					}
+					part += string(r)
+					pw += rw
+				}
+				lines = append(lines, part)
+				word = word[len(part):]
+				ww = textwidth(word, font, size)
+			}
+			switch {
+			case line == "":
+				line, lw = word, ww
+			case lw+space+ww <= width:
+				line += " " + word
+				lw += space + ww
+			default:
+				lines = append(lines, line)
+				line, lw = word, ww
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// TextWrap places s as left-aligned lines wrapped to width, starting with the baseline
+// of the first line at (x,y), spaced by leading times the size (default 2).
+// Unlike TextBlock, the lines are broken by the generator, using font metrics,
+// so that they are the same with every renderer. The baseline of the next line is returned.
+func (p *DeckGen) TextWrap(x, y float64, s string, width float64, font string, size, leading float64, color string) float64 {
+	return p.TextLines(x, y, wraplines(s, p.fontof(font), p.sizeof(size), width), font, size, leading, color)
+}
+
+// TextFit places s at (x,y) at the largest size at which it fits within maxWidth,
+// measured with font metrics, and returns the size.
+func (p *DeckGen) TextFit(x, y float64, s, font string, maxWidth float64, color string) float64 {
+	w := textwidth(s, p.fontof(font), 1)
+	if w == 0 {
+		return 0
+	}
+	size := maxWidth / w
+	p.Text(x, y, s, font, size, color)
+	return size
+}
+
+// trackedwidth measures s with tracking (extra space between characters, as a fraction of the size).
+func trackedwidth(s, font string, size, tracking float64) float64 {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+	return textwidth(s, font, size) + float64(n-1)*tracking*size
+}
+
+// TextTracked places s aligned at (x,y) with tracking: extra space between the characters,
+// as a fraction of the size (negative values tighten). Each character is placed as its own
+// text element, at offsets computed from font metrics. The width of the text is returned.
+func (p *DeckGen) TextTracked(x, y float64, s, font string, size, tracking float64, color string) float64 {
+	if s == "" {
+		return 0
+	}
+	f, sz := p.fontof(font), p.sizeof(size)
+	start := x
+	for _, r := range s {
+		if r != ' ' {
+			p.Text(x, y, string(r), font, size, color)
+		}
+		x += (runewidth(r, f) + tracking) * sz
+	}
+	return x - start - tracking*sz
+}
+
+// TextTrackedMid places tracked text centered at (x,y), like TextTracked.
+func (p *DeckGen) TextTrackedMid(x, y float64, s, font string, size, tracking float64, color string) float64 {
+	w := trackedwidth(s, p.fontof(font), p.sizeof(size), tracking)
+	return p.TextTracked(x-w/2, y, s, font, size, tracking, color)
+}
+
+// Superscripts and subscripts are set at scriptratio of the size of the text they follow,
+// raised or lowered by a fraction of that size.
+const (
+	scriptratio = 0.6
+	superrise   = 0.4
+	subdrop     = 0.2
+)
+
+// TextSuper places s as a superscript to text of the given size with its baseline at y,
+// starting at x, for example after the width of the base text. The width of the superscript is returned.
+func (p *DeckGen) TextSuper(x, y float64, s, font string, size float64, color string) float64 {
+	size = p.sizeof(size)
+	p.Text(x, y+p.hpct(size*superrise), s, font, size*scriptratio, color)
+	return textwidth(s, p.fontof(font), size*scriptratio)
+}
+
+// TextSub places s as a subscript to text of the given size with its baseline at y,
+// starting at x, like TextSuper. The width of the subscript is returned.
+func (p *DeckGen) TextSub(x, y float64, s, font string, size float64, color string) float64 {
+	size = p.sizeof(size)
+	p.Text(x, y-p.hpct(size*subdrop), s, font, size*scriptratio, color)
+	return textwidth(s, p.fontof(font), size*scriptratio)
+}
+
+// TextVertical places s with its characters stacked top to bottom, centered on x, starting with
+// the baseline of the first character at y, as in vertical CJK layouts. The y below the text is returned.
+// For text running along a vertical axis, such as an axis label, use TextRotate.
+func (p *DeckGen) TextVertical(x, y float64, s, font string, size float64, color string) float64 {
+	step := p.hpct(p.sizeof(size) * 1.1)
+	for _, r := range s {
+		if r != ' ' {
+			p.TextMid(x, y, string(r), font, size, color)
+		}
+		y -= step
+	}
+	return y
+}
@@ -0,0 +1,28 @@
+package deckgen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrapLines(t *testing.T) {
+	// in mono at size 1, each character is 0.6 wide: 5 fit in a width of 3
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"", []string{""}},
+		{"ab cd", []string{"ab cd"}},
+		{"ab cd ef", []string{"ab cd", "ef"}},
+		{"  ab   cd  ", []string{"ab cd"}},
+		{"abcdefghijkl", []string{"abcde", "fghij", "kl"}},
+		{"x abcdefg", []string{"x", "abcde", "fg"}},
+		{"ab\ncd", []string{"ab", "cd"}},
+		{"ab\n\ncd", []string{"ab", "", "cd"}},
+	}
+	for _, test := range tests {
+		if got := wraplines(test.s, "mono", 1, 3); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("wraplines(%q) = %q, want %q", test.s, got, test.want)
+		}
+	}
+}
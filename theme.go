@@ -0,0 +1,210 @@
+package deckgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// Theme is the look of the slides made by the slide helpers, such as TitleSlide, and of
+// generated tables and report slides. Unset fields use those of the deck's theme (see SetTheme),
+// then those of the default theme.
+type Theme struct {
+	Bg, Fg    string  // slide background and text colors (default: white, black)
+	Accent    string  // color of rules, highlights and table headers (default: steelblue)
+	Muted     string  // color of secondary text (default: gray)
+	Font      string  // text font (default: sans)
+	TitleFont string  // title font (default: Font)
+	Mono      string  // font of code (default: mono)
+	Size      float64 // size of body text, such as bullets (default: 3)
+	TitleSize float64 // size of slide titles (default: 4.5)
+}
+
+// defaulttheme is the theme used when none is given.
+var defaulttheme = Theme{Bg: "white", Fg: "black", Accent: "steelblue", Muted: "gray", Font: "sans", Mono: "mono", Size: 3, TitleSize: 4.5}
+
+// SetTheme sets the theme of the deck, used by the slide helpers when they are not given one,
+// and to complete those that are. Whole decks can be restyled by changing the theme.
+func (p *DeckGen) SetTheme(t Theme) {
+	p.theme = t
+}
+
+// merge returns t with unset fields taken from d.
+func (t Theme) merge(d Theme) Theme {
+	if t.Bg == "" {
+		t.Bg = d.Bg
+	}
+	if t.Fg == "" {
+		t.Fg = d.Fg
+	}
+	if t.Accent == "" {
+		t.Accent = d.Accent
+	}
+	if t.Muted == "" {
+		t.Muted = d.Muted
+	}
+	if t.Font == "" {
+		t.Font = d.Font
+	}
+	if t.TitleFont == "" {
+		t.TitleFont = d.TitleFont
+	}
+	if t.Mono == "" {
+		t.Mono = d.Mono
+	}
+	if t.Size == 0 {
+		t.Size = d.Size
+	}
+	if t.TitleSize == 0 {
+		t.TitleSize = d.TitleSize
+	}
+	return t
+}
+
+// themeof returns the optional theme, completed with the deck's theme and the defaults.
+func (p *DeckGen) themeof(theme []Theme) Theme {
+	var t Theme
+	if len(theme) > 0 {
+		t = theme[0]
+	}
+	t = t.merge(p.theme).merge(defaulttheme)
+	if t.TitleFont == "" {
+		t.TitleFont = t.Font
+	}
+	return t
+}
+
+// fitsize returns size, reduced if needed so that s fits within width.
+func fitsize(s, font string, size, width float64) float64 {
+	if w := textwidth(s, font, size); w > width {
+		return math.Max(size*width/w, size/3)
+	}
+	return size
+}
+
+// TitleSlide makes an opening slide, with the title and subtitle centered above and below
+// a rule, and the author and date below. Empty fields are omitted.
+func (p *DeckGen) TitleSlide(title, subtitle, author, date string, theme ...Theme) {
+	t := p.themeof(theme)
+	p.StartSlideContext(SlideContext{Bg: t.Bg, Fg: t.Fg, Font: t.Font})
+	tf := p.fontof(t.TitleFont)
+	p.TextMid(50, 58, title, t.TitleFont, fitsize(title, tf, t.TitleSize*4/3, 90), t.Fg)
+	p.Line(30, 53, 70, 53, 0.4, t.Accent)
+	if subtitle != "" {
+		p.TextMid(50, 45, subtitle, t.Font, fitsize(subtitle, p.fontof(t.Font), t.Size, 90), t.Muted)
+	}
+	byline := author
+	if date != "" {
+		if byline != "" {
+			byline += " · "
+		}
+		byline += date
+	}
+	if byline != "" {
+		p.TextMid(50, 20, byline, t.Font, t.Size*2/3, t.Fg)
+	}
+	p.EndSlide()
+}
+
+// Section is a section of a deck, recorded by SectionSlide.
+type Section struct {
+	Title  string
+	Number int // section number, or 0
+	Slide  int // number of the divider slide, counting from 1
+}
+
+// Sections returns the sections begun so far, for example to make a table of contents.
+func (p *DeckGen) Sections() []Section {
+	return p.sections
+}
+
+// SectionSlide makes a divider slide for a section, with a large centered title above an
+// accent bar, and the section number, if n is not 0. The section is recorded; see Sections.
+func (p *DeckGen) SectionSlide(title string, n int, theme ...Theme) {
+	t := p.themeof(theme)
+	p.StartSlideContext(SlideContext{Bg: t.Bg, Fg: t.Fg, Font: t.Font})
+	p.sections = append(p.sections, Section{Title: title, Number: n, Slide: p.nslides})
+	if n != 0 {
+		p.TextMid(50, 62, fmt.Sprintf("%02d", n), t.TitleFont, t.TitleSize*16/9, t.Muted, 60)
+	}
+	p.TextMid(50, 45, title, t.TitleFont, fitsize(title, p.fontof(t.TitleFont), t.TitleSize*10/9, 90), t.Fg)
+	p.Rect(50, 38, 20, 1, t.Accent)
+	p.EndSlide()
+}
+
+// bulletsize returns the largest size, from size down to a third of it, at which the bullets,
+// wrapped to width, fit within height.
+func (p *DeckGen) bulletsize(bullets []string, font string, size, width, height float64) float64 {
+	min := size / 3
+	for ; size > min; size *= 0.9 {
+		n := 0
+		for _, b := range bullets {
+			n += len(wraplines(b, font, size, width))
+		}
+		if float64(n)*p.hpct(size*leading(0)) <= height {
+			break
+		}
+	}
+	return math.Max(size, min)
+}
+
+// BulletSlide makes a slide with a title at the top left and a bulleted list below it.
+// The bullets are made smaller, as needed, to fit on the slide.
+func (p *DeckGen) BulletSlide(title string, bullets []string, theme ...Theme) {
+	t := p.themeof(theme)
+	p.StartSlideContext(SlideContext{Bg: t.Bg, Fg: t.Fg, Font: t.Font})
+	p.Text(10, 88, title, t.TitleFont, fitsize(title, p.fontof(t.TitleFont), t.TitleSize, 80), t.Fg)
+	p.Line(10, 84, 90, 84, 0.2, t.Accent)
+	size := p.bulletsize(bullets, p.fontof(t.Font), t.Size, 75, 70)
+	p.List(12, 76, size, 0, 75, bullets, "bullet", t.Font, t.Fg)
+	p.EndSlide()
+}
+
+// ImageSlideOptions are optional settings of ImageSlide.
+type ImageSlideOptions struct {
+	Credit string  // credit line, set small at the lower right
+	Margin float64 // margin around the image, in canvas percentages (default 5)
+	Embed  bool    // include the image in the deck as a data URI
+	Theme  Theme
+}
+
+// ImageSlide makes a slide showing the named image, scaled to fit within the margins
+// with its aspect ratio preserved, and the caption, if any, centered below it.
+// Image names are as for ImageFile.
+func (p *DeckGen) ImageSlide(name, caption string, opts ...ImageSlideOptions) error {
+	var o ImageSlideOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Margin <= 0 {
+		o.Margin = 5
+	}
+	name, w, h, err := p.imagesource(name, o.Embed)
+	if err != nil {
+		return err
+	}
+	if w == 0 || h == 0 {
+		return fmt.Errorf("%s: empty image", name)
+	}
+	t := p.themeof([]Theme{o.Theme})
+	bottom := o.Margin
+	if caption != "" {
+		bottom += 6
+	}
+	if o.Credit != "" && caption == "" {
+		bottom += 3
+	}
+	aw := (100 - 2*o.Margin) * float64(p.width) / 100
+	ah := (100 - o.Margin - bottom) * float64(p.height) / 100
+	scale := math.Min(aw/float64(w), ah/float64(h)) * 100
+
+	p.StartSlideContext(SlideContext{Bg: t.Bg, Fg: t.Fg, Font: t.Font})
+	p.image(Image{Width: w, Height: h, Name: name, Scale: scale, CommonAttr: CommonAttr{Xp: 50, Yp: (100 - o.Margin + bottom) / 2}})
+	if caption != "" {
+		p.TextMid(50, bottom-4, caption, t.Font, fitsize(caption, p.fontof(t.Font), t.Size*2/3, 100-2*o.Margin), t.Fg)
+	}
+	if o.Credit != "" {
+		p.TextEnd(100-o.Margin, o.Margin/2, o.Credit, t.Font, t.Size*0.4, t.Muted)
+	}
+	p.EndSlide()
+	return nil
+}
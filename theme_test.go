@@ -0,0 +1,99 @@
+package deckgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHelperTheme(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		make func(p *DeckGen) error
+		want []string
+	}{
+		{"hbars", func(p *DeckGen) error {
+			p.hbars(Box{X: 10, Y: 10, W: 80, H: 70}, []string{"a"}, []float64{1}, "red")
+			return nil
+		}, []string{`font="serif"`, `color="navy"`, `color="silver"`}},
+		{"pie", func(p *DeckGen) error {
+			p.pie(50, 50, 20, []string{"a", "b"}, []float64{1, 2}, []string{"red"})
+			return nil
+		}, []string{`font="serif"`, `color="navy"`}},
+		{"chess", func(p *DeckGen) error {
+			return p.Chess(50, 50, 40, "8/8/8/8/8/8/8/4K3 w - - 0 1")
+		}, []string{`font="serif"`, `color="silver"`}},
+		{"tree", func(p *DeckGen) error {
+			return p.TreeFS(dir, 1)
+		}, []string{`font="courier"`, `color="navy"`}},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.SetTheme(Theme{Fg: "navy", Muted: "silver", Font: "serif", Mono: "courier"})
+		if err := test.make(p); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		p.Flush()
+		out := buf.String()
+		for _, w := range test.want {
+			if !strings.Contains(out, w) {
+				t.Errorf("%s: output has no %s", test.name, w)
+			}
+		}
+		if strings.Contains(out, `font="sans"`) {
+			t.Errorf("%s: output ignores the theme font", test.name)
+		}
+	}
+}
+
+func TestReportTheme(t *testing.T) {
+	logs := `{"time":"2024-01-01T00:00:00Z","level":"info","msg":"start"}
+{"time":"2024-01-01T00:05:00Z","level":"error","msg":"failed"}
+`
+	bench := "BenchmarkA-8 1000 200 ns/op\nBenchmarkA-8 1000 210 ns/op\n"
+	after := "BenchmarkA-8 1000 100 ns/op\nBenchmarkA-8 1000 110 ns/op\n"
+	tests := []struct {
+		name string
+		make func(p *DeckGen) error
+	}{
+		{"logs", func(p *DeckGen) error { return p.LogSlides(strings.NewReader(logs), 10) }},
+		{"bench", func(p *DeckGen) error {
+			return p.BenchSlides(strings.NewReader(bench), strings.NewReader(after))
+		}},
+		{"summary", func(p *DeckGen) error { return p.Summarize(strings.NewReader("a,b\n1,x\n2,y\n")) }},
+		{"csv", func(p *DeckGen) error { return p.TableFromCSV(strings.NewReader("a,b\n1,2\n")) }},
+		{"modgraph", func(p *DeckGen) error { return p.ModGraphSlides(strings.NewReader("m a@v1\na@v1 b@v2\n")) }},
+		{"openapi", func(p *DeckGen) error {
+			return p.OpenAPISlides(strings.NewReader(`{"openapi":"3.0.0","info":{"title":"T","version":"1"},
+				"components":{"schemas":{"A":{"type":"object","properties":{"b":{"$ref":"#/components/schemas/B"}}},"B":{"type":"string"}}}}`))
+		}},
+		{"diff", func(p *DeckGen) error { p.CodeDiff("a\nb\n", "a\nc\n", "Go", true); return nil }},
+		{"countdown", func(p *DeckGen) error { p.Countdown(1); return nil }},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.SetTheme(Theme{Bg: "navy", Fg: "white", Accent: "gold", Muted: "silver", Font: "serif", Mono: "courier"})
+		p.StartDeck()
+		if err := test.make(p); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		p.EndDeck()
+		out := buf.String()
+		if n, themed := strings.Count(out, "<slide"), strings.Count(out, `<slide bg="navy" fg="white"`); n == 0 || n != themed {
+			t.Errorf("%s: %d of %d slides in the theme colors", test.name, themed, n)
+		}
+		for _, literal := range []string{`font="sans"`, `font="mono"`, `color="black"`, `color="gray"`, `color="steelblue"`} {
+			if strings.Contains(out, literal) {
+				t.Errorf("%s: output has %s", test.name, literal)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package deckgen
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// TOCOptions are optional settings of TOCSlide.
+type TOCOptions struct {
+	Title       string // slide title (default: Agenda)
+	PageNumbers bool   // list the slide number of each section
+	Theme       Theme
+}
+
+// tocslide is a table of contents to be inserted when the deck is assembled.
+type tocslide struct {
+	position int
+	TOCOptions
+}
+
+// TOCSlide inserts, as slide number position, an agenda slide listing the sections of the
+// deck, as made by SectionSlide, including those made after the call. Since the sections
+// are not known until EndDeck, the output of the following slides is held until then.
+// A position before the next slide, or after the last, places the agenda there.
+func (p *DeckGen) TOCSlide(position int, opts ...TOCOptions) {
+	t := &tocslide{position: position}
+	if len(opts) > 0 {
+		t.TOCOptions = opts[0]
+	}
+	if t.Title == "" {
+		t.Title = "Agenda"
+	}
+	if next := p.nslides + 1; t.position < next {
+		t.position = next
+	}
+	p.toc = t
+	p.deferslides()
+}
+
+// inserttoc inserts the agenda slide into the deferred markup, renumbering the slides after it.
+func (p *DeckGen) inserttoc(markup []byte) []byte {
+	t := p.toc
+	p.toc = nil
+	at, ok := p.slideat[t.position]
+	if !ok {
+		at = len(markup)
+		t.position = p.nslides + 1
+	}
+	for i, s := range p.sections {
+		if s.Slide >= t.position {
+			p.sections[i].Slide++
+		}
+	}
+	after := slidemarker.ReplaceAllFunc(markup[at:], func(m []byte) []byte {
+		n, _ := strconv.Atoi(string(m[1 : len(m)-1]))
+		return []byte(fmt.Sprintf("\x00%d\x00", n+1))
+	})
+	total := p.nslides + 1
+	p.nslides = t.position - 1
+	agenda := p.Capture(func(p *DeckGen) { p.agenda(t.TOCOptions) })
+	p.nslides = total
+	var b bytes.Buffer
+	b.Write(markup[:at])
+	b.WriteString(agenda)
+	b.Write(after)
+	return b.Bytes()
+}
+
+// agenda makes the agenda slide.
+func (p *DeckGen) agenda(o TOCOptions) {
+	t := p.themeof([]Theme{o.Theme})
+	p.StartSlideContext(SlideContext{Bg: t.Bg, Fg: t.Fg, Font: t.Font})
+	p.Text(10, 88, o.Title, t.TitleFont, fitsize(o.Title, p.fontof(t.TitleFont), t.TitleSize, 80), t.Fg)
+	p.Line(10, 84, 90, 84, 0.2, t.Accent)
+	size := t.Size * 0.85
+	if n := len(p.sections); n > 0 {
+		size = math.Min(size, 70/p.hpct(leading(0))/float64(n))
+	}
+	y := 76.0
+	for _, s := range p.sections {
+		x := 12.0
+		if s.Number != 0 {
+			p.TextEnd(x+size*1.5, y, strconv.Itoa(s.Number), t.Font, size, t.Accent)
+			x += size * 2.5
+		}
+		p.Text(x, y, s.Title, t.Font, size, t.Fg)
+		if o.PageNumbers {
+			p.TextEnd(88, y, strconv.Itoa(s.Slide), t.Font, size, t.Muted)
+		}
+		y -= p.hpct(size * leading(0))
+	}
+	p.EndSlide()
+}
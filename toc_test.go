@@ -0,0 +1,54 @@
+package deckgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTOCSlide(t *testing.T) {
+	tests := []struct {
+		name     string
+		position int
+		agenda   int // slide number of the agenda
+		sections []string
+	}{
+		{name: "mid-deck", position: 2, agenda: 2, sections: []string{">One<", ">3<"}},
+		{name: "first", position: 0, agenda: 1, sections: []string{">One<", ">3<"}},
+		{name: "past the end", position: 10, agenda: 5, sections: []string{">One<", ">2<"}},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		p.EnablePageNumbers("", "", PageNumberOptions{SkipFirst: true})
+		p.StartDeck()
+		p.TOCSlide(test.position, TOCOptions{PageNumbers: true})
+		p.StartSlide()
+		p.Text(10, 50, "title", "sans", 3, "")
+		p.EndSlide()
+		p.SectionSlide("One", 1)
+		for i := 0; i < 2; i++ {
+			p.StartSlide()
+			p.EndSlide()
+		}
+		p.EndDeck()
+		out := buf.String()
+		want := []string{"", "2 / 5", "3 / 5", "4 / 5", "5 / 5"}
+		if got := slidenumbers(out); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("%s: page numbers %q, want %q", test.name, got, want)
+		}
+		slides := strings.Split(out, "<slide")[1:]
+		if len(slides) != 5 {
+			t.Fatalf("%s: %d slides, want 5", test.name, len(slides))
+		}
+		agenda := slides[test.agenda-1]
+		if !strings.Contains(agenda, ">Agenda<") {
+			t.Errorf("%s: slide %d is not the agenda", test.name, test.agenda)
+		}
+		for _, w := range test.sections {
+			if !strings.Contains(agenda, w) {
+				t.Errorf("%s: agenda lacks %q", test.name, w)
+			}
+		}
+	}
+}
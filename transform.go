@@ -0,0 +1,229 @@
+package deckgen
+
+import "math"
+
+// Transform is a 2D affine transformation matrix, mapping (x, y) to
+// (A*x + C*y + E, B*x + D*y + F).
+type Transform struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity returns the transform that leaves points unchanged.
+func Identity() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// Translate returns a transform that moves points by (dx, dy).
+func Translate(dx, dy float64) Transform {
+	return Transform{A: 1, D: 1, E: dx, F: dy}
+}
+
+// Scale returns a transform that scales points by (sx, sy) about the origin.
+func Scale(sx, sy float64) Transform {
+	return Transform{A: sx, D: sy}
+}
+
+// ScaleAbout returns a transform that scales points by (sx, sy) about (x, y).
+func ScaleAbout(sx, sy, x, y float64) Transform {
+	return Translate(-x, -y).Then(Scale(sx, sy)).Then(Translate(x, y))
+}
+
+// Rotate returns a transform that rotates points counter-clockwise by deg degrees about the origin.
+func Rotate(deg float64) Transform {
+	s, c := math.Sincos(deg * math.Pi / 180)
+	return Transform{A: c, B: s, C: -s, D: c}
+}
+
+// RotateAbout returns a transform that rotates points counter-clockwise by deg degrees about (x, y).
+// Since deck coordinates are percentages, shapes on a non-square canvas are distorted;
+// use DeckGen.RotateAbout to rotate in true proportions.
+func RotateAbout(deg, x, y float64) Transform {
+	return Translate(-x, -y).Then(Rotate(deg)).Then(Translate(x, y))
+}
+
+// Shear returns a transform that shears points by kx horizontally and ky vertically.
+func Shear(kx, ky float64) Transform {
+	return Transform{A: 1, B: ky, C: kx, D: 1}
+}
+
+// MirrorX returns a transform that reflects points across the vertical line at x.
+func MirrorX(x float64) Transform {
+	return Transform{A: -1, D: 1, E: 2 * x}
+}
+
+// MirrorY returns a transform that reflects points across the horizontal line at y.
+func MirrorY(y float64) Transform {
+	return Transform{A: 1, D: -1, F: 2 * y}
+}
+
+// Mirror returns a transform that reflects points across the line through (x, y) at deg degrees.
+func Mirror(deg, x, y float64) Transform {
+	s, c := math.Sincos(2 * deg * math.Pi / 180)
+	return Translate(-x, -y).Then(Transform{A: c, B: s, C: s, D: -c}).Then(Translate(x, y))
+}
+
+// Then returns the transform that applies t followed by u.
+func (t Transform) Then(u Transform) Transform {
+	return Transform{
+		A: u.A*t.A + u.C*t.B,
+		B: u.B*t.A + u.D*t.B,
+		C: u.A*t.C + u.C*t.D,
+		D: u.B*t.C + u.D*t.D,
+		E: u.A*t.E + u.C*t.F + u.E,
+		F: u.B*t.E + u.D*t.F + u.F,
+	}
+}
+
+// Invert returns the inverse transform, and false if t cannot be inverted.
+func (t Transform) Invert() (Transform, bool) {
+	det := t.A*t.D - t.B*t.C
+	if det == 0 {
+		return Identity(), false
+	}
+	return Transform{
+		A: t.D / det,
+		B: -t.B / det,
+		C: -t.C / det,
+		D: t.A / det,
+		E: (t.C*t.F - t.D*t.E) / det,
+		F: (t.B*t.E - t.A*t.F) / det,
+	}, true
+}
+
+// ApplyPoint transforms a single point.
+func (t Transform) ApplyPoint(pt Point) Point {
+	return Point{X: t.A*pt.X + t.C*pt.Y + t.E, Y: t.B*pt.X + t.D*pt.Y + t.F}
+}
+
+// Apply transforms a set of points, returning a new slice.
+func (t Transform) Apply(pts []Point) []Point {
+	out := make([]Point, len(pts))
+	for i, pt := range pts {
+		out[i] = t.ApplyPoint(pt)
+	}
+	return out
+}
+
+// ScaleFactor returns the average linear scale of the transform, used for sizes.
+func (t Transform) ScaleFactor() float64 {
+	return math.Sqrt(math.Abs(t.A*t.D - t.B*t.C))
+}
+
+// Angle returns the rotation of the transform, in degrees.
+func (t Transform) Angle() float64 {
+	return math.Atan2(t.B, t.A) * 180 / math.Pi
+}
+
+// RotateAbout returns a transform that rotates points by deg degrees about (x, y),
+// correcting for the aspect ratio of the canvas so that shapes keep their proportions.
+func (p *DeckGen) RotateAbout(deg, x, y float64) Transform {
+	if p.width == 0 || p.height == 0 {
+		return RotateAbout(deg, x, y)
+	}
+	aspect := float64(p.height) / float64(p.width)
+	return Translate(-x, -y).Then(Scale(1, aspect)).Then(Rotate(deg)).Then(Scale(1, 1/aspect)).Then(Translate(x, y))
+}
+
+// xform is a transform group pushed by PushTransform.
+type xform struct {
+	t            Transform
+	scale, angle float64
+}
+
+// PushTransform begins a transform group: until the matching PopTransform, element
+// coordinates are scaled by scale and rotated by rotation degrees (in true proportions)
+// about the origin, then moved by (dx, dy). Sizes are scaled, and rotations of text and
+// shapes adjusted, to match. Groups nest, so that a component drawn in local coordinates
+// may itself be placed within another group.
+func (p *DeckGen) PushTransform(dx, dy, scale, rotation float64) {
+	t := Scale(scale, scale).Then(p.RotateAbout(rotation, 0, 0)).Then(Translate(dx, dy))
+	g := xform{t: t, scale: scale, angle: rotation}
+	if n := len(p.xforms); n > 0 {
+		outer := p.xforms[n-1]
+		g = xform{t: t.Then(outer.t), scale: scale * outer.scale, angle: rotation + outer.angle}
+	}
+	p.xforms = append(p.xforms, g)
+}
+
+// PopTransform ends the innermost transform group.
+func (p *DeckGen) PopTransform() {
+	if n := len(p.xforms); n > 0 {
+		p.xforms = p.xforms[:n-1]
+	}
+}
+
+// xfpoint applies the current transform group to a point.
+func (p *DeckGen) xfpoint(x, y float64) (float64, float64) {
+	if len(p.xforms) == 0 {
+		return x, y
+	}
+	pt := p.xforms[len(p.xforms)-1].t.ApplyPoint(Point{X: x, Y: y})
+	return pt.X, pt.Y
+}
+
+// xfpoints applies the current transform group to coordinate slices, returning new slices.
+func (p *DeckGen) xfpoints(x, y []float64) ([]float64, []float64) {
+	if len(p.xforms) == 0 || len(x) != len(y) {
+		return x, y
+	}
+	tx := make([]float64, len(x))
+	ty := make([]float64, len(y))
+	for i := range x {
+		tx[i], ty[i] = p.xfpoint(x[i], y[i])
+	}
+	return tx, ty
+}
+
+// xfscale and xfangle return the scale and rotation of the current transform group.
+func (p *DeckGen) xfscale() float64 {
+	if len(p.xforms) == 0 {
+		return 1
+	}
+	return p.xforms[len(p.xforms)-1].scale
+}
+
+func (p *DeckGen) xfangle() float64 {
+	if len(p.xforms) == 0 {
+		return 0
+	}
+	return p.xforms[len(p.xforms)-1].angle
+}
+
+// xfdim applies the current transform group to a dimension.
+func (p *DeckGen) xfdim(d Dimension) Dimension {
+	if len(p.xforms) == 0 {
+		return d
+	}
+	s := p.xfscale()
+	d.Xp, d.Yp = p.xfpoint(d.Xp, d.Yp)
+	d.Wp *= s
+	d.Hp *= s
+	d.Rotation += p.xfangle()
+	return d
+}
+
+// xftext applies the current transform group to text.
+func (p *DeckGen) xftext(t Text) Text {
+	if len(p.xforms) == 0 {
+		return t
+	}
+	s := p.xfscale()
+	t.Xp, t.Yp = p.xfpoint(t.Xp, t.Yp)
+	t.Sp = p.sizeof(t.Sp) * s
+	t.Wp *= s
+	t.Rotation += p.xfangle()
+	return t
+}
+
+// xflist applies the current transform group to a list.
+func (p *DeckGen) xflist(l List) List {
+	if len(p.xforms) == 0 {
+		return l
+	}
+	s := p.xfscale()
+	l.Xp, l.Yp = p.xfpoint(l.Xp, l.Yp)
+	l.Sp = p.sizeof(l.Sp) * s
+	l.Wp *= s
+	l.Rotation += p.xfangle()
+	return l
+}
@@ -0,0 +1,207 @@
+package deckgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// TreeOptions controls the appearance of filesystem tree slides.
+// Zero values select defaults.
+type TreeOptions struct {
+	Area   Box      // region occupied (default: most of the canvas)
+	Map    bool     // draw a treemap sized by bytes, instead of an indented tree
+	Font   string   // text font (default: the theme's mono font for trees, its text font for maps)
+	Size   float64  // text size (default: 1.5)
+	Color  string   // text color (default: the theme's foreground)
+	Colors []string // treemap fill colors, cycled by depth
+}
+
+// fsnode is a file or directory with its total size.
+type fsnode struct {
+	name       string
+	dir        bool
+	unreadable bool
+	size       int64
+	children   []*fsnode
+}
+
+// walkfs reads the tree rooted at path. Sizes include everything below,
+// but children are only kept to the given depth. Entries below path that cannot
+// be read are kept, marked unreadable.
+func walkfs(path string, depth int) (*fsnode, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	n := &fsnode{name: info.Name(), dir: info.IsDir(), size: info.Size()}
+	if !n.dir {
+		return n, nil
+	}
+	n.size = 0
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		c, err := walkfs(filepath.Join(path, e.Name()), depth-1)
+		if err != nil {
+			c = &fsnode{name: e.Name(), dir: e.IsDir(), unreadable: true}
+		}
+		n.size += c.size
+		if depth > 0 {
+			n.children = append(n.children, c)
+		}
+	}
+	sort.Slice(n.children, func(i, j int) bool {
+		if n.children[i].dir != n.children[j].dir {
+			return n.children[i].dir
+		}
+		return n.children[i].name < n.children[j].name
+	})
+	return n, nil
+}
+
+// humanbytes formats a byte count with a binary unit suffix.
+func humanbytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// TreeFS walks the filesystem at path to the given depth, and draws its structure
+// on the current slide, either as an indented tree or as a treemap sized by bytes.
+// The options are optional. Unreadable entries below path are marked in trees, and left out of maps.
+func (p *DeckGen) TreeFS(path string, depth int, opts ...TreeOptions) error {
+	root, err := walkfs(path, depth)
+	if err != nil {
+		return err
+	}
+	var o TreeOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if root.name == "." || root.name == "" {
+		root.name = path
+	}
+	if o.Area.Empty() {
+		o.Area = Box{X: 5, Y: 5, W: 90, H: 85}
+	}
+	if o.Size == 0 {
+		o.Size = 1.5
+	}
+	t := p.themeof(nil)
+	if o.Color == "" {
+		o.Color = t.Fg
+	}
+	if o.Map {
+		if o.Font == "" {
+			o.Font = t.Font
+		}
+		if len(o.Colors) == 0 {
+			o.Colors = []string{t.Accent, "lightsteelblue", "lightblue", "powderblue"}
+		}
+		p.treemap(root, o.Area, 0, true, o)
+		return nil
+	}
+	if o.Font == "" {
+		o.Font = t.Mono
+	}
+	p.treelines(root, o)
+	return nil
+}
+
+// treelines draws the tree as indented lines, truncating when the area is full.
+func (p *DeckGen) treelines(root *fsnode, opts TreeOptions) {
+	a := opts.Area
+	lh := p.hpct(opts.Size) * 1.8
+	y := a.Top() - p.hpct(opts.Size)
+	var draw func(n *fsnode, prefix, branch string) bool
+	draw = func(n *fsnode, prefix, branch string) bool {
+		if y-lh < a.Y {
+			p.Text(a.X, y, "…", opts.Font, opts.Size, opts.Color)
+			return false
+		}
+		name := n.name
+		if n.dir {
+			name += "/"
+		}
+		size := humanbytes(n.size)
+		if n.unreadable {
+			name += " (unreadable)"
+			size = "?"
+		}
+		p.Text(a.X, y, prefix+branch+name, opts.Font, opts.Size, opts.Color)
+		p.TextEnd(a.Right(), y, size, opts.Font, opts.Size, opts.Color, 60)
+		y -= lh
+		child := prefix
+		switch branch {
+		case "├── ":
+			child += "│   "
+		case "└── ":
+			child += "    "
+		}
+		for i, c := range n.children {
+			b := "├── "
+			if i == len(n.children)-1 {
+				b = "└── "
+			}
+			if !draw(c, child, b) {
+				return false
+			}
+		}
+		return true
+	}
+	draw(root, "", "")
+}
+
+// treemap draws n into box b, dividing the space among children in proportion to their size,
+// alternating horizontal and vertical slices at each level. Boxes no larger than
+// the padding are skipped.
+func (p *DeckGen) treemap(n *fsnode, b Box, level int, horizontal bool, opts TreeOptions) {
+	cx, cy := b.Center()
+	pad := 0.2
+	if b.W <= pad || b.H <= p.hpct(pad) {
+		return
+	}
+	p.Rect(cx, cy, b.W-pad, b.H-p.hpct(pad), opts.Colors[level%len(opts.Colors)])
+	label := n.name + " " + humanbytes(n.size)
+	if len(n.children) == 0 || n.size == 0 {
+		if textwidth(label, opts.Font, opts.Size) < b.W && p.hpct(opts.Size)*2 < b.H {
+			p.TextMid(cx, cy-p.hpct(opts.Size)/2, label, opts.Font, opts.Size, opts.Color)
+		}
+		return
+	}
+	inner := b
+	if p.hpct(opts.Size)*3 < b.H && textwidth(label, opts.Font, opts.Size) < b.W {
+		p.Text(b.X+pad, b.Top()-p.hpct(opts.Size)*1.3, label, opts.Font, opts.Size, opts.Color)
+		inner.H -= p.hpct(opts.Size) * 2
+	}
+	inner = inner.Inset(pad, p.hpct(pad))
+	if inner.W <= 0 || inner.H <= 0 {
+		return
+	}
+	off := 0.0
+	for _, c := range n.children {
+		if c.size == 0 {
+			continue
+		}
+		f := float64(c.size) / float64(n.size)
+		cb := inner
+		if horizontal {
+			cb.X, cb.W = inner.X+off*inner.W, f*inner.W
+		} else {
+			cb.Y, cb.H = inner.Top()-(off+f)*inner.H, f*inner.H
+		}
+		off += f
+		p.treemap(c, cb, level+1, !horizontal, opts)
+	}
+}
@@ -0,0 +1,78 @@
+package deckgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTreeFS(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a/big.txt", "a/small.txt", "b/c/d.txt", "locked/x.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		size := 10
+		if strings.Contains(name, "big") {
+			size = 100000
+		}
+		if err := os.WriteFile(path, bytes.Repeat([]byte("x"), size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	locked := filepath.Join(dir, "locked")
+	if err := os.Chmod(locked, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(locked, 0o755)
+	_, err := os.ReadDir(locked)
+	canlock := err != nil
+
+	negative := regexp.MustCompile(`[wh]p="-`)
+	tests := []struct {
+		name string
+		opts []TreeOptions
+		want []string
+	}{
+		{"tree", nil, []string{"a/", "big.txt", "locked/"}},
+		{"map", []TreeOptions{{Map: true}}, []string{"big.txt"}},
+		{"small map", []TreeOptions{{Map: true, Area: Box{X: 10, Y: 10, W: 2, H: 2}}}, nil},
+		{"full tree", []TreeOptions{{Area: Box{X: 10, Y: 10, W: 80, H: 12}, Size: 2}}, []string{"…"}},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewSlides(&buf, 1024, 768)
+		if err := p.TreeFS(dir, 3, test.opts...); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		p.Flush()
+		out := buf.String()
+		for _, w := range test.want {
+			if !strings.Contains(out, w) {
+				t.Errorf("%s: output has no %s", test.name, w)
+			}
+		}
+		if texts := regexp.MustCompile(`<text xp="[^"]*" yp="([^"]*)"[^>]*>([^<]*)</text>`).FindAllStringSubmatch(out, -1); len(texts) > 0 {
+			seen := map[string]string{}
+			for _, m := range texts {
+				if prev, ok := seen[m[1]]; ok && (m[2] == "…" || prev == "…") {
+					t.Errorf("%s: %q overprints %q at y %s", test.name, m[2], prev, m[1])
+				}
+				seen[m[1]] = m[2]
+			}
+		}
+		if negative.MatchString(out) {
+			t.Errorf("%s: output has negative dimensions: %s", test.name, out)
+		}
+		if test.opts == nil && canlock && !strings.Contains(out, "locked/ (unreadable)") {
+			t.Errorf("%s: output does not mark the unreadable directory", test.name)
+		}
+	}
+	if _, err := walkfs(filepath.Join(dir, "missing"), 1); err == nil {
+		t.Error("walkfs of a missing path succeeded")
+	}
+}
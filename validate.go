@@ -0,0 +1,46 @@
+package deckgen
+
+import (
+	"fmt"
+	"log"
+)
+
+// StrictMode selects how elements with coordinates or opacity outside 0-100 are reported.
+type StrictMode int
+
+const (
+	StrictOff   StrictMode = iota // elements are not checked
+	StrictWarn                    // out of range elements are logged
+	StrictError                   // the first out of range element is reported by Err
+)
+
+// SetStrict sets the checking of element coordinates and opacity.
+// Out of range elements are emitted in every mode.
+func (p *DeckGen) SetStrict(mode StrictMode) {
+	p.strict = mode
+}
+
+// check reports elem if opacity or any of coords is outside of 0-100.
+func (p *DeckGen) check(elem interface{}, opacity float64, coords ...float64) {
+	if p.strict == StrictOff {
+		return
+	}
+	var err error
+	if opacity < 0 || opacity > 100 {
+		err = fmt.Errorf("%T opacity %.2f out of range: %+v", elem, opacity, elem)
+	}
+	for _, c := range coords {
+		if err == nil && (c < 0 || c > 100) {
+			err = fmt.Errorf("%T coordinate %.2f out of range: %+v", elem, c, elem)
+		}
+	}
+	if err == nil {
+		return
+	}
+	switch {
+	case p.strict == StrictWarn:
+		log.Print(err)
+	case p.strictErr == nil:
+		p.strictErr = err
+	}
+}